@@ -0,0 +1,472 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"proxy_manager/pkg/client"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// pageLimit is a page size used to fetch all proxies.
+const pageLimit = 1000
+
+func runList(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet()
+	filterFlags := addFilterFlags(fs)
+	limit := fs.Int64("limit", 50, "page size")
+	offset := fs.Int64("offset", 0, "offset in proxy list")
+	cursor := fs.String("cursor", "", "next cursor of previous page")
+	all := fs.Bool("all", false, "list all proxies, instead of one page")
+	output := outputFlag(fs)
+	if err := a.parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	filter, err := filterFlags.filter()
+	if err != nil {
+		return err
+	}
+
+	var list client.ProxyList
+	if *all {
+		list.Proxies, err = listAllProxies(ctx, a.client, filter)
+	} else {
+		list, err = a.client.ListProxies(ctx, client.ListOptions{ProxyFilter: filter, Offset: *offset, Limit: *limit, Cursor: *cursor})
+	}
+	if err != nil {
+		return err
+	}
+
+	if *output == outputJSON {
+		return writeJSON(a.stdout, list)
+	}
+	if err := writeProxyTable(a.stdout, list.Proxies); err != nil {
+		return err
+	}
+	// Paging info goes to stderr, so stdout has only the table
+	if list.Total != nil {
+		fmt.Fprintf(a.stderr, "total: %d\n", *list.Total)
+	}
+	if list.NextCursor != "" {
+		fmt.Fprintf(a.stderr, "next cursor: %s\n", list.NextCursor)
+	}
+	return nil
+}
+
+// listAllProxies fetches all proxies matching filter page by page.
+func listAllProxies(ctx context.Context, c *client.Client, filter client.ProxyFilter) ([]client.Proxy, error) {
+	opts := client.ListOptions{ProxyFilter: filter, Limit: pageLimit, WithoutTotal: true}
+	proxies := []client.Proxy{}
+	for {
+		list, err := c.ListProxies(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, list.Proxies...)
+		if list.NextCursor == "" {
+			return proxies, nil
+		}
+		opts.Cursor = list.NextCursor
+	}
+}
+
+func runGet(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet()
+	output := outputFlag(fs)
+	if err := a.parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	proxyID, err := parseProxyID(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	proxy, err := a.client.GetProxy(ctx, proxyID)
+	if err != nil {
+		return err
+	}
+	return writeProxy(a.stdout, *output, &proxy)
+}
+
+func runAdd(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet()
+	proxyFlags := addProxyFlags(fs, client.ProtocolHTTP)
+	output := outputFlag(fs)
+	if err := a.parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	if proxyFlags.host == "" || proxyFlags.port == 0 || proxyFlags.expires == "" {
+		fs.Usage()
+		return usageErrorf("-host, -port and -expires are required")
+	}
+
+	expirationDate, err := parseTime(proxyFlags.expires)
+	if err != nil {
+		return err
+	}
+	data := client.ProxyData{
+		Protocol:       proxyFlags.protocol,
+		Host:           proxyFlags.host,
+		Port:           proxyFlags.port,
+		Username:       proxyFlags.username,
+		ExpirationDate: expirationDate,
+		MaxOccupies:    proxyFlags.maxOccupies,
+		Tags:           splitList(proxyFlags.tags),
+	}
+	if proxyFlags.password != "" {
+		data.Password = &proxyFlags.password
+	}
+	if setFlags(fs)["weight"] {
+		data.Weight = &proxyFlags.weight
+	}
+
+	proxy, err := a.client.CreateProxy(ctx, data)
+	if err != nil {
+		return err
+	}
+	return writeProxy(a.stdout, *output, &proxy)
+}
+
+func runImport(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet()
+	format := fs.String("format", client.ImportFormatText, "list format (text, csv, json)")
+	protocol := fs.String("protocol", client.ProtocolHTTP, "protocol of proxies without protocol")
+	expires := fs.String("expires", "", "expiration date of proxies without it, RFC 3339 time or duration from now")
+	tags := fs.String("tags", "", "comma separated tags added to every proxy")
+	skipDuplicates := fs.Bool("skip-duplicates", false, "skip already existing proxies")
+	partial := fs.Bool("partial", false, "import valid lines, even if some lines are invalid")
+	output := outputFlag(fs)
+	if err := a.parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+
+	opts := client.ImportOptions{
+		Format:          *format,
+		DefaultProtocol: *protocol,
+		Tags:            splitList(*tags),
+		SkipDuplicates:  *skipDuplicates,
+		Partial:         *partial,
+	}
+	if *expires != "" {
+		var err error
+		if opts.DefaultExpirationDate, err = parseTime(*expires); err != nil {
+			return err
+		}
+	}
+
+	r := io.Reader(os.Stdin)
+	if fs.Arg(0) != "-" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	result, importErr := a.client.ImportProxies(ctx, r, opts)
+	if importErr != nil && len(result.Errors) == 0 {
+		return importErr
+	}
+
+	if *output == outputJSON {
+		if err := writeJSON(a.stdout, result); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(a.stdout, "imported: %d\nskipped: %d\n", result.Imported, result.Skipped)
+		for _, lineErr := range result.Errors {
+			fmt.Fprintf(a.stderr, "line %d: %s\n", lineErr.Line, lineErr.Error)
+		}
+	}
+	return importErr
+}
+
+func runUpdate(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet()
+	proxyFlags := addProxyFlags(fs, "")
+	version := fs.Int64("version", 0, "update only if proxy has this version")
+	output := outputFlag(fs)
+	if err := a.parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	proxyID, err := parseProxyID(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var patch client.ProxyPatch
+	set := setFlags(fs)
+	if set["protocol"] {
+		patch.Protocol = &proxyFlags.protocol
+	}
+	if set["host"] {
+		patch.Host = &proxyFlags.host
+	}
+	if set["port"] {
+		patch.Port = &proxyFlags.port
+	}
+	if set["username"] {
+		patch.Username = &proxyFlags.username
+	}
+	if set["password"] {
+		patch.Password = &proxyFlags.password
+	}
+	if set["expires"] {
+		expirationDate, err := parseTime(proxyFlags.expires)
+		if err != nil {
+			return err
+		}
+		patch.ExpirationDate = &expirationDate
+	}
+	if set["max-occupies"] {
+		patch.MaxOccupies = &proxyFlags.maxOccupies
+	}
+	if set["weight"] {
+		patch.Weight = &proxyFlags.weight
+	}
+	if set["tags"] {
+		tags := splitList(proxyFlags.tags)
+		if tags == nil {
+			tags = []string{}
+		}
+		patch.Tags = &tags
+	}
+
+	proxy, err := a.client.PatchProxy(ctx, proxyID, *version, patch)
+	if err != nil {
+		return err
+	}
+	return writeProxy(a.stdout, *output, &proxy)
+}
+
+func runDelete(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet()
+	version := fs.Int64("version", 0, "delete only if proxy has this version, allowed for single proxy")
+	if err := a.parseFlags(fs, args, 1, -1); err != nil {
+		return err
+	}
+	if *version != 0 && fs.NArg() > 1 {
+		return usageErrorf("-version is allowed only for single proxy")
+	}
+
+	proxyIDs := make([]int64, 0, fs.NArg())
+	for _, arg := range fs.Args() {
+		proxyID, err := parseProxyID(arg)
+		if err != nil {
+			return err
+		}
+		proxyIDs = append(proxyIDs, proxyID)
+	}
+
+	for _, proxyID := range proxyIDs {
+		if err := a.client.DeleteProxy(ctx, proxyID, *version); err != nil {
+			return fmt.Errorf("proxy %d: %w", proxyID, err)
+		}
+	}
+	return nil
+}
+
+func runOccupy(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet()
+	pool := fs.String("pool", "", "occupy proxy of pool")
+	protocol := fs.String("protocol", "", "proxy protocol (http, https, socks5)")
+	hostPattern := fs.String("host-pattern", "", "glob pattern of proxy host")
+	minLifetime := fs.Duration("min-lifetime", 0, "min time until proxy expiration")
+	excludeIDs := fs.String("exclude-ids", "", "comma separated IDs of proxies to skip")
+	ttl := fs.Duration("ttl", 0, "occupy lifetime, server default if 0")
+	exclusive := fs.Bool("exclusive", false, "occupy only free proxy and don't share it until release")
+	wait := fs.Duration("wait", 0, "how long to wait for available proxy")
+	strategy := fs.String("strategy", "", "strategy of picking proxy")
+	targetDomain := fs.String("target-domain", "", "skip proxies banned for this domain")
+	tagsAny := fs.String("tags-any", "", "proxy has at least one of comma separated tags")
+	tagsAll := fs.String("tags-all", "", "proxy has all of comma separated tags")
+	output := outputFlag(fs)
+	if err := a.parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+
+	req := client.OccupyRequest{
+		Protocol:     *protocol,
+		HostPattern:  *hostPattern,
+		MinLifetime:  *minLifetime,
+		TTL:          *ttl,
+		Exclusive:    *exclusive,
+		Wait:         *wait,
+		Strategy:     *strategy,
+		TargetDomain: *targetDomain,
+		AnyTags:      splitList(*tagsAny),
+		AllTags:      splitList(*tagsAll),
+	}
+	for _, s := range splitList(*excludeIDs) {
+		proxyID, err := parseProxyID(s)
+		if err != nil {
+			return err
+		}
+		req.ExcludeIDs = append(req.ExcludeIDs, proxyID)
+	}
+
+	var occupy client.ProxyOccupy
+	var err error
+	if *pool != "" {
+		occupy, err = a.client.OccupyPoolProxy(ctx, *pool, req)
+	} else {
+		occupy, err = a.client.OccupyProxy(ctx, req)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *output == outputJSON {
+		return writeJSON(a.stdout, occupy)
+	}
+	return writeTable(a.stdout, []string{"KEY", "PROXY_ID", "URL", "EXPIRES_AT"}, [][]string{{
+		occupy.Key,
+		strconv.FormatInt(occupy.Proxy.ID, 10),
+		occupy.Proxy.URL().String(),
+		formatTime(&occupy.ExpiresAt),
+	}})
+}
+
+func runRelease(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet()
+	status := fs.String("status", "", "outcome of proxy usage (success, failure, banned, timeout, captcha)")
+	reason := fs.String("reason", "", "reason of outcome")
+	bytes := fs.Int64("bytes", 0, "bytes transferred through proxy")
+	latency := fs.Duration("latency", 0, "latency of requests through proxy")
+	if err := a.parseFlags(fs, args, 1, 1); err != nil {
+		return err
+	}
+
+	var outcome *client.Outcome
+	if *status != "" {
+		outcome = &client.Outcome{Status: *status, Reason: *reason, Bytes: *bytes, Latency: *latency}
+	} else if *reason != "" || *bytes != 0 || *latency != 0 {
+		return usageErrorf("-reason, -bytes and -latency require -status")
+	}
+	return a.client.ReleaseProxy(ctx, fs.Arg(0), outcome)
+}
+
+func runExport(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet()
+	filterFlags := addFilterFlags(fs)
+	format := fs.String("format", client.ExportFormatURL, "export format (url, colon, csv, json, clash)")
+	out := fs.String("out", "-", "output file, - for stdout")
+	if err := a.parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	filter, err := filterFlags.filter()
+	if err != nil {
+		return err
+	}
+
+	if *out == "-" {
+		return a.client.ExportProxies(ctx, a.stdout, *format, filter)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := a.client.ExportProxies(ctx, f, *format, filter); err != nil {
+		f.Close()
+		os.Remove(*out)
+		return err
+	}
+	return f.Close()
+}
+
+// proxyStats is a summary of proxy list.
+type proxyStats struct {
+	Total      int64            `json:"total"`
+	Enabled    int64            `json:"enabled"`
+	Healthy    int64            `json:"healthy"`
+	Occupied   int64            `json:"occupied"`
+	Occupies   int64            `json:"occupies"`
+	CoolingOff int64            `json:"cooling_off"`
+	Protocols  map[string]int64 `json:"protocols"`
+}
+
+func runStats(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet()
+	filterFlags := addFilterFlags(fs)
+	output := outputFlag(fs)
+	if err := a.parseFlags(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	filter, err := filterFlags.filter()
+	if err != nil {
+		return err
+	}
+
+	proxies, err := listAllProxies(ctx, a.client, filter)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	stats := proxyStats{Protocols: map[string]int64{}}
+	for _, p := range proxies {
+		stats.Total++
+		stats.Protocols[p.Protocol]++
+		stats.Occupies += p.OccupiesCount
+		if p.Enabled {
+			stats.Enabled++
+		}
+		if p.Healthy {
+			stats.Healthy++
+		}
+		if p.OccupiesCount > 0 {
+			stats.Occupied++
+		}
+		if p.CooldownUntil != nil && p.CooldownUntil.After(now) {
+			stats.CoolingOff++
+		}
+	}
+
+	if *output == outputJSON {
+		return writeJSON(a.stdout, stats)
+	}
+
+	rows := [][]string{
+		{"total", fmt.Sprint(stats.Total)},
+		{"enabled", fmt.Sprint(stats.Enabled)},
+		{"healthy", fmt.Sprint(stats.Healthy)},
+		{"occupied", fmt.Sprint(stats.Occupied)},
+		{"occupies", fmt.Sprint(stats.Occupies)},
+		{"cooling off", fmt.Sprint(stats.CoolingOff)},
+	}
+	protocols := make([]string, 0, len(stats.Protocols))
+	for protocol := range stats.Protocols {
+		protocols = append(protocols, protocol)
+	}
+	sort.Strings(protocols)
+	for _, protocol := range protocols {
+		rows = append(rows, []string{"protocol " + protocol, fmt.Sprint(stats.Protocols[protocol])})
+	}
+	return writeTable(a.stdout, []string{"STAT", "COUNT"}, rows)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"flag"
+	"proxy_manager/pkg/client"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// optionalBool is a bool flag, that distinguishes unset value from false.
+type optionalBool struct {
+	value *bool
+}
+
+func (b *optionalBool) String() string {
+	if b.value == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b.value)
+}
+
+func (b *optionalBool) Set(s string) error {
+	value, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	b.value = &value
+	return nil
+}
+
+func (b *optionalBool) IsBoolFlag() bool {
+	return true
+}
+
+// parseTime parses RFC 3339 time or duration from now, e.g. 720h.
+func parseTime(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, usageErrorf("invalid time %q, expected RFC 3339 time or duration from now", s)
+	}
+	return t, nil
+}
+
+// splitList splits comma separated list, empty string is an empty list.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// filterFlags are flags of proxy list filter shared by list, export and stats.
+type filterFlags struct {
+	tagsAny       string
+	tagsAll       string
+	pool          string
+	enabled       optionalBool
+	protocol      string
+	host          string
+	port          int64
+	expiresAfter  string
+	expiresBefore string
+	occupied      optionalBool
+	sort          string
+	desc          bool
+}
+
+func addFilterFlags(fs *flag.FlagSet) *filterFlags {
+	f := &filterFlags{}
+	fs.StringVar(&f.tagsAny, "tags-any", "", "proxy has at least one of comma separated tags")
+	fs.StringVar(&f.tagsAll, "tags-all", "", "proxy has all of comma separated tags")
+	fs.StringVar(&f.pool, "pool", "", "name of pool, that contains proxy")
+	fs.Var(&f.enabled, "enabled", "proxy is not expired (true or false)")
+	fs.StringVar(&f.protocol, "protocol", "", "proxy protocol (http, https, socks5)")
+	fs.StringVar(&f.host, "host", "", "case-insensitive substring of host")
+	fs.Int64Var(&f.port, "port", 0, "proxy port")
+	fs.StringVar(&f.expiresAfter, "expires-after", "", "proxy expires after RFC 3339 time or duration from now")
+	fs.StringVar(&f.expiresBefore, "expires-before", "", "proxy expires before RFC 3339 time or duration from now")
+	fs.Var(&f.occupied, "occupied", "proxy is occupied (true or false)")
+	fs.StringVar(&f.sort, "sort", "", "sort field (id, expiration_date, occupies_count, latency)")
+	fs.BoolVar(&f.desc, "desc", false, "sort in descending order")
+	return f
+}
+
+func (f *filterFlags) filter() (client.ProxyFilter, error) {
+	filter := client.ProxyFilter{
+		AnyTags:      splitList(f.tagsAny),
+		AllTags:      splitList(f.tagsAll),
+		Pool:         f.pool,
+		Enabled:      f.enabled.value,
+		Protocol:     f.protocol,
+		HostContains: f.host,
+		Port:         f.port,
+		Occupied:     f.occupied.value,
+		Sort:         f.sort,
+		Desc:         f.desc,
+	}
+
+	for _, t := range []struct {
+		value string
+		dst   **time.Time
+	}{{f.expiresAfter, &filter.ExpiresAfter}, {f.expiresBefore, &filter.ExpiresBefore}} {
+		if t.value == "" {
+			continue
+		}
+		parsed, err := parseTime(t.value)
+		if err != nil {
+			return client.ProxyFilter{}, err
+		}
+		*t.dst = &parsed
+	}
+	return filter, nil
+}
+
+// proxyFlags are flags of proxy fields shared by add and update.
+type proxyFlags struct {
+	protocol    string
+	host        string
+	port        int64
+	username    string
+	password    string
+	expires     string
+	maxOccupies int64
+	weight      int64
+	tags        string
+}
+
+func addProxyFlags(fs *flag.FlagSet, defaultProtocol string) *proxyFlags {
+	p := &proxyFlags{}
+	fs.StringVar(&p.protocol, "protocol", defaultProtocol, "proxy protocol (http, https, socks5)")
+	fs.StringVar(&p.host, "host", "", "proxy host")
+	fs.Int64Var(&p.port, "port", 0, "proxy port")
+	fs.StringVar(&p.username, "username", "", "proxy username")
+	fs.StringVar(&p.password, "password", "", "proxy password")
+	fs.StringVar(&p.expires, "expires", "", "expiration date, RFC 3339 time or duration from now, e.g. 720h")
+	fs.Int64Var(&p.maxOccupies, "max-occupies", 0, "max count of simultaneous occupies, 0 means unlimited")
+	fs.Int64Var(&p.weight, "weight", 1, "weight of proxy for weighted_random strategy")
+	fs.StringVar(&p.tags, "tags", "", "comma separated tags")
+	return p
+}
+
+// outputFlag adds -o flag of output format.
+func outputFlag(fs *flag.FlagSet) *string {
+	return fs.String("o", outputTable, "output format (table, json)")
+}
+
+func checkOutput(output string) error {
+	if output != outputTable && output != outputJSON {
+		return usageErrorf("invalid output format %q, expected %s or %s", output, outputTable, outputJSON)
+	}
+	return nil
+}
+
+// parseProxyID parses proxy ID argument.
+func parseProxyID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, usageErrorf("invalid proxy id %q", s)
+	}
+	return id, nil
+}
+
+// setFlags returns names of flags set in command line.
+func setFlags(fs *flag.FlagSet) map[string]bool {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	return set
+}
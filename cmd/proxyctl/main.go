@@ -0,0 +1,203 @@
+// Command proxyctl administers proxy manager through its HTTP API.
+//
+// Usage:
+//
+//	proxyctl [-url URL] [-token TOKEN] [-timeout DURATION] <command> [flags] [args]
+//
+// URL and token default to PROXYCTL_URL and PROXYCTL_TOKEN environment variables.
+// Exit codes: 0 - success, 1 - error, 2 - invalid usage, 3 - not found, 4 - conflict
+// (already exists or version mismatch), 5 - no available proxy.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"proxy_manager/pkg/client"
+	"sort"
+	"time"
+)
+
+// Exit codes.
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+	exitNotFound
+	exitConflict
+	exitUnavailable
+)
+
+const defaultURL = "http://localhost:9000"
+
+// usageError is an error of command line arguments.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usageErrorf(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+type command struct {
+	usage   string // arguments of command
+	summary string
+	run     func(ctx context.Context, app *app, args []string) error
+}
+
+var commands = map[string]command{
+	"list":    {usage: "[flags]", summary: "list proxies", run: runList},
+	"get":     {usage: "[flags] <proxy_id>", summary: "show proxy", run: runGet},
+	"add":     {usage: "[flags]", summary: "create proxy", run: runAdd},
+	"import":  {usage: "[flags] <file|->", summary: "import proxy list from file or stdin", run: runImport},
+	"update":  {usage: "[flags] <proxy_id>", summary: "change given fields of proxy", run: runUpdate},
+	"delete":  {usage: "[flags] <proxy_id>...", summary: "delete proxies", run: runDelete},
+	"occupy":  {usage: "[flags]", summary: "occupy proxy", run: runOccupy},
+	"release": {usage: "[flags] <key>", summary: "release proxy occupy", run: runRelease},
+	"export":  {usage: "[flags]", summary: "export proxy list", run: runExport},
+	"stats":   {usage: "[flags]", summary: "show proxy statistics", run: runStats},
+}
+
+// app is a state shared by commands.
+type app struct {
+	name   string // name of running command
+	cmd    command
+	client *client.Client
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("proxyctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	baseURL := fs.String("url", envOr("PROXYCTL_URL", defaultURL), "base URL of proxy manager")
+	token := fs.String("token", os.Getenv("PROXYCTL_TOKEN"), "API token")
+	timeout := fs.Duration("timeout", time.Minute, "timeout of command")
+	credentials := fs.Bool("credentials", false, "show proxy passwords, requires token of admin API key")
+	fs.Usage = func() { printUsage(fs) }
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "proxyctl: unknown command %q\n", fs.Arg(0))
+		fs.Usage()
+		return exitUsage
+	}
+
+	var opts []client.Option
+	if *token != "" {
+		opts = append(opts, client.WithToken(*token))
+	}
+	if *credentials {
+		opts = append(opts, client.WithCredentials())
+	}
+	c, err := client.New(*baseURL, opts...)
+	if err != nil {
+		fmt.Fprintf(stderr, "proxyctl: %s\n", err)
+		return exitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	err = cmd.run(ctx, &app{name: fs.Arg(0), cmd: cmd, client: c, stdout: stdout, stderr: stderr}, fs.Args()[1:])
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(stderr, "proxyctl %s: %s\n", fs.Arg(0), err)
+	}
+	return exitCode(err)
+}
+
+// exitCode returns exit code of command error.
+func exitCode(err error) int {
+	var usageErr *usageError
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.Is(err, client.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, client.ErrAlreadyExists), errors.Is(err, client.ErrVersionMismatch):
+		return exitConflict
+	case errors.Is(err, client.ErrProxiesSaturated):
+		return exitUnavailable
+	default:
+		return exitError
+	}
+}
+
+func printUsage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "Usage: proxyctl [flags] <command> [command flags] [args]")
+	fmt.Fprintln(w, "\nCommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-8s %s\n", name, commands[name].summary)
+	}
+
+	fmt.Fprintln(w, "\nFlags:")
+	fs.PrintDefaults()
+	fmt.Fprintln(w, "\nRun 'proxyctl <command> -h' for command flags.")
+}
+
+// newFlagSet returns flag set of running command, which prints errors to stderr.
+func (a *app) newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet(a.name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: proxyctl %s %s\n\n%s\n\nFlags:\n", a.name, a.cmd.usage, a.cmd.summary)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses command flags and checks count of positional arguments, max < 0 means unlimited.
+func (a *app) parseFlags(fs *flag.FlagSet, args []string, min, max int) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &usageError{msg: err.Error()}
+	}
+	if fs.NArg() < min || max >= 0 && fs.NArg() > max {
+		fs.Usage()
+		return usageErrorf("expected %s", a.cmd.usage)
+	}
+	return nil
+}
+
+func envOr(name, def string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return def
+}
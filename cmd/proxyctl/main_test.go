@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/proxies":
+			if r.URL.Query().Get("protocol") != "socks5" || r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": "invalid request"}`))
+				return
+			}
+			w.Write([]byte(`{"proxies": [{"proxy_id": 1, "protocol": "socks5", "host": "127.0.0.1", "port": 1080}], "offset": 0, "total": 1}`))
+		case "/api/v1/proxies/occupy":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error": "all proxies saturated"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "proxy not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{args: []string{"list", "-protocol", "socks5", "-o", "json"}, wantCode: exitOK, wantStdout: `"host": "127.0.0.1"`},
+		{args: []string{"list", "-protocol", "socks5"}, wantCode: exitOK, wantStdout: "socks5", wantStderr: "total: 1"},
+		{args: []string{"list"}, wantCode: exitError, wantStderr: "invalid request"},
+		{args: []string{"get", "2"}, wantCode: exitNotFound, wantStderr: "proxy not found"},
+		{args: []string{"get", "x"}, wantCode: exitUsage},
+		{args: []string{"occupy"}, wantCode: exitUnavailable},
+		{args: []string{"list", "-o", "yaml"}, wantCode: exitUsage},
+		{args: []string{"unknown"}, wantCode: exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"-url", server.URL, "-token", "secret"}, tt.args...)
+
+			code := run(args, &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Fatalf("expected exit code %d, got %d, stderr: %s", tt.wantCode, code, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Fatalf("expected stdout to contain %q, got %q", tt.wantStdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Fatalf("expected stderr to contain %q, got %q", tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRun_ListJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"proxies": [{"proxy_id": 1}, {"proxy_id": 2}], "offset": 0}`))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-url", server.URL, "list", "-all", "-o", "json"}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("unexpected exit code %d, stderr: %s", code, stderr.String())
+	}
+
+	var list struct {
+		Proxies []struct {
+			ID int64 `json:"proxy_id"`
+		} `json:"proxies"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &list); err != nil || len(list.Proxies) != 2 {
+		t.Fatalf("unexpected output %q: %v", stdout.String(), err)
+	}
+}
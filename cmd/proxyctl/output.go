@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"proxy_manager/pkg/client"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Output formats.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeTable writes tab separated rows aligned in columns.
+func writeTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return "-"
+	}
+	return strings.Join(tags, ",")
+}
+
+var proxyTableHeader = []string{"ID", "PROTOCOL", "HOST", "PORT", "USERNAME", "ENABLED", "HEALTHY", "OCCUPIES", "EXPIRES", "TAGS"}
+
+func proxyTableRow(p *client.Proxy) []string {
+	username := p.Username
+	if username == "" {
+		username = "-"
+	}
+	return []string{
+		fmt.Sprint(p.ID),
+		p.Protocol,
+		p.Host,
+		fmt.Sprint(p.Port),
+		username,
+		fmt.Sprint(p.Enabled),
+		fmt.Sprint(p.Healthy),
+		fmt.Sprint(p.OccupiesCount),
+		formatTime(&p.ExpirationDate),
+		formatTags(p.Tags),
+	}
+}
+
+func writeProxyTable(w io.Writer, proxies []client.Proxy) error {
+	rows := make([][]string, 0, len(proxies))
+	for i := range proxies {
+		rows = append(rows, proxyTableRow(&proxies[i]))
+	}
+	return writeTable(w, proxyTableHeader, rows)
+}
+
+// writeProxy writes all fields of proxy in given output format.
+func writeProxy(w io.Writer, output string, p *client.Proxy) error {
+	if output == outputJSON {
+		return writeJSON(w, p)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, field := range [][2]interface{}{
+		{"ID", p.ID},
+		{"Version", p.Version},
+		{"URL", p.URL()},
+		{"Expiration date", formatTime(&p.ExpirationDate)},
+		{"Enabled", p.Enabled},
+		{"Healthy", p.Healthy},
+		{"Check failures", p.CheckFailures},
+		{"Check error", p.CheckError},
+		{"Checked at", formatTime(p.CheckedAt)},
+		{"Latency", time.Duration(p.Latency) * time.Millisecond},
+		{"Occupies", p.OccupiesCount},
+		{"Max occupies", p.MaxOccupies},
+		{"Last occupied at", formatTime(p.LastOccupiedAt)},
+		{"Weight", p.Weight},
+		{"Score", p.Score},
+		{"Cooldown until", formatTime(p.CooldownUntil)},
+		{"Tags", formatTags(p.Tags)},
+	} {
+		fmt.Fprintf(tw, "%s:\t%v\n", field[0], field[1])
+	}
+	return tw.Flush()
+}
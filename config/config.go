@@ -8,11 +8,32 @@ import (
 
 type Config struct {
 	HTTPPort string `env:"HTTP_PORT" env-default:"9000"`
+	GRPCPort string `env:"GRPC_PORT" env-default:"9090"`
 
 	PostgresURL     string `env:"PG_URL" env-required:"true"`
 	PostgresMaxCons int    `env:"PG_MAX_CONS" env-default:"15"`
 
-	OccupiesExpireTime int `env:"OCCUPIES_EXPIRE_TIME" env-default:"5"`
+	OccupiesExpireTime    int `env:"OCCUPIES_EXPIRE_TIME"     env-default:"5"`
+	OccupiesMaxExpireTime int `env:"OCCUPIES_MAX_EXPIRE_TIME" env-default:"120"`
+	OccupiesMaxWaitTime   int `env:"OCCUPIES_MAX_WAIT_TIME"   env-default:"60"`
+
+	OccupyStrategy string `env:"OCCUPY_STRATEGY" env-default:"least_occupied"`
+
+	OutcomeScoreDecay float64 `env:"OUTCOME_SCORE_DECAY" env-default:"0.1"`
+	OutcomeMinScore   float64 `env:"OUTCOME_MIN_SCORE"   env-default:"0.3"`
+	OutcomeCooldown   int     `env:"OUTCOME_COOLDOWN"    env-default:"300"`
+
+	BanCooldown int `env:"BAN_COOLDOWN" env-default:"3600"`
+
+	HealthCheckEnabled     bool   `env:"HEALTH_CHECK_ENABLED"      env-default:"true"`
+	HealthCheckURL         string `env:"HEALTH_CHECK_URL"          env-default:"https://www.google.com/generate_204"`
+	HealthCheckInterval    int    `env:"HEALTH_CHECK_INTERVAL"     env-default:"60"`
+	HealthCheckTimeout     int    `env:"HEALTH_CHECK_TIMEOUT"      env-default:"10"`
+	HealthCheckMaxFailures int    `env:"HEALTH_CHECK_MAX_FAILURES" env-default:"3"`
+	HealthCheckWorkers     int    `env:"HEALTH_CHECK_WORKERS"      env-default:"10"`
+
+	AuthEnabled bool   `env:"AUTH_ENABLED"  env-default:"true"`
+	AdminAPIKey string `env:"ADMIN_API_KEY"`
 
 	ServeSwagger bool   `env:"SERVE_SWAGGER" env-default:"true"`
 	LogLevel     string `env:"LOG_LEVEL"     env-default:"info"`
@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"proxy_manager/config"
+	grpcv1 "proxy_manager/internal/controller/grpc/v1"
 	v1 "proxy_manager/internal/controller/http/v1"
+	"proxy_manager/internal/domain"
+	"proxy_manager/internal/infrastructure/checker"
 	"proxy_manager/internal/infrastructure/repository"
 	"proxy_manager/internal/usecase"
 	"proxy_manager/pkg/logger"
@@ -17,8 +21,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
 )
 
+// minAdminAPIKeyLength is the minimal length of bootstrap admin token, that is not easy to guess.
+const minAdminAPIKeyLength = 16
+
 func Run(cfg *config.Config) {
 	rootCtx := context.Background()
 	l := logger.New(cfg.LogLevel)
@@ -30,15 +38,53 @@ func Run(cfg *config.Config) {
 		log.Fatal(err)
 	}
 
-	proxyRepo := repository.NewPostgresProxyRepository(rootCtx, pgxPool,
-		time.Minute*time.Duration(cfg.OccupiesExpireTime), l)
-	u := usecase.New(proxyRepo)
+	if err := usecase.ValidateStrategy(cfg.OccupyStrategy); err != nil {
+		log.Fatal(err)
+	}
+
+	scoring := domain.ProxyScoring{
+		Decay:    cfg.OutcomeScoreDecay,
+		MinScore: cfg.OutcomeMinScore,
+		Cooldown: time.Second * time.Duration(cfg.OutcomeCooldown),
+	}
+	if err := scoring.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	if cfg.HealthCheckEnabled && (cfg.HealthCheckInterval <= 0 || cfg.HealthCheckTimeout <= 0 || cfg.HealthCheckMaxFailures <= 0) {
+		log.Fatal("health check interval, timeout and max failures must be > 0")
+	}
+
+	proxyRepo := repository.NewPostgresProxyRepository(rootCtx, pgxPool, l)
+	poolRepo := repository.NewPostgresPoolRepository(pgxPool)
+	u := usecase.New(proxyRepo, poolRepo, time.Minute*time.Duration(cfg.OccupiesExpireTime),
+		time.Minute*time.Duration(cfg.OccupiesMaxExpireTime), time.Second*time.Duration(cfg.OccupiesMaxWaitTime),
+		cfg.OccupyStrategy, scoring, time.Second*time.Duration(cfg.BanCooldown))
+	u.StartOccupyWaitersDispatcher(rootCtx, l)
+
+	if cfg.HealthCheckEnabled {
+		proxyChecker := checker.NewHTTPProxyChecker(cfg.HealthCheckURL, time.Second*time.Duration(cfg.HealthCheckTimeout), nil)
+		healthChecker := usecase.NewHealthChecker(proxyRepo, proxyChecker, time.Second*time.Duration(cfg.HealthCheckInterval),
+			int64(cfg.HealthCheckMaxFailures), cfg.HealthCheckWorkers, l)
+		healthChecker.Start(rootCtx)
+	}
+
+	if cfg.AdminAPIKey != "" && len(cfg.AdminAPIKey) < minAdminAPIKeyLength {
+		log.Fatalf("admin API key must have at least %d chars", minAdminAPIKeyLength)
+	}
+	if !cfg.AuthEnabled {
+		l.Warn("API key authentication is disabled, every client has admin access")
+	}
+	auth := usecase.NewAuth(repository.NewPostgresAPIKeyRepository(pgxPool), cfg.AuthEnabled, cfg.AdminAPIKey)
 
 	handler := gin.New()
 
-	v1.NewRouter(handler, u, l, cfg.ServeSwagger)
+	v1.NewRouter(handler, u, auth, l, cfg.ServeSwagger)
 	httpServer := serveHTTPInBackground(errorChan, handler, fmt.Sprintf(":%s", cfg.HTTPPort))
 
+	grpcServer := grpcv1.NewServer(u, auth, l)
+	serveGRPCInBackground(errorChan, grpcServer, fmt.Sprintf(":%s", cfg.GRPCPort))
+
 	// For graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -57,10 +103,39 @@ func Run(cfg *config.Config) {
 		l.Fatal("httpServer shutdown:", err)
 	}
 
+	l.Info("Shutdown grpcServer...")
+	shutdownGRPC(ctx, grpcServer)
+
 	<-ctx.Done()
 	l.Info("httpServer exited!")
 }
 
+func serveGRPCInBackground(errorChan chan<- error, srv *grpc.Server, addr string) {
+	go func() {
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			errorChan <- fmt.Errorf("grpcServer: %w", err)
+			return
+		}
+		errorChan <- fmt.Errorf("grpcServer: %w", srv.Serve(lis))
+	}()
+}
+
+// shutdownGRPC waits for active calls to finish until ctx is done, then closes remaining connections.
+func shutdownGRPC(ctx context.Context, srv *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		srv.Stop()
+	}
+}
+
 func serveHTTPInBackground(errorChan chan<- error, handler *gin.Engine, addr string) *http.Server {
 	srv := &http.Server{
 		Addr:              addr,
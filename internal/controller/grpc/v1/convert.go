@@ -0,0 +1,124 @@
+package v1
+
+import (
+	"proxy_manager/internal/domain"
+	proxyv1 "proxy_manager/pkg/api/proxy/v1"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProtoProxy(p domain.Proxy) *proxyv1.Proxy {
+	return &proxyv1.Proxy{
+		Id:             p.ID,
+		Protocol:       p.Protocol,
+		Username:       p.Username,
+		Password:       p.Password,
+		Host:           p.Host,
+		Port:           p.Port,
+		OccupiesCount:  p.OccupiesCount,
+		ExpirationDate: timestamppb.New(p.ExpirationDate),
+		Enabled:        p.Enabled,
+		Healthy:        p.Healthy,
+		CheckFailures:  p.CheckFailures,
+		Latency:        p.Latency,
+		CheckError:     p.CheckError,
+		CheckedAt:      toProtoTime(p.CheckedAt),
+		MaxOccupies:    p.MaxOccupies,
+		Weight:         p.Weight,
+		LastOccupiedAt: toProtoTime(p.LastOccupiedAt),
+		Score:          p.Score,
+		CooldownUntil:  toProtoTime(p.CooldownUntil),
+		Tags:           p.Tags,
+		Version:        p.Version,
+	}
+}
+
+func toProtoProxyOccupy(o domain.ProxyOccupy) *proxyv1.ProxyOccupy {
+	return &proxyv1.ProxyOccupy{
+		Proxy:     toProtoProxy(o.Proxy),
+		Key:       o.Key,
+		ExpiresAt: timestamppb.New(o.ExpiresAt),
+		Exclusive: o.Exclusive,
+	}
+}
+
+// toProtoTime converts optional time, nil is kept as nil.
+func toProtoTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// fromProtoTime converts optional timestamp, nil is kept as nil.
+func fromProtoTime(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.AsTime()
+	return &v
+}
+
+func fromProtoProxyData(id int64, data *proxyv1.ProxyData) domain.Proxy {
+	proxy := domain.Proxy{
+		ID:             id,
+		Protocol:       data.GetProtocol(),
+		Host:           data.GetHost(),
+		Port:           data.GetPort(),
+		Username:       data.GetUsername(),
+		Password:       data.GetPassword(),
+		ExpirationDate: data.GetExpirationDate().AsTime(),
+		MaxOccupies:    data.GetMaxOccupies(),
+		Weight:         domain.DefaultProxyWeight,
+		Tags:           data.GetTags(),
+	}
+	if data != nil && data.Weight != nil {
+		proxy.Weight = *data.Weight
+	}
+	return proxy
+}
+
+func fromProtoOccupyRequest(req *proxyv1.OccupyProxyRequest) domain.OccupyOptions {
+	return domain.OccupyOptions{
+		Protocol:    req.GetProtocol(),
+		HostPattern: req.GetHostPattern(),
+		MinLifetime: time.Second * time.Duration(req.GetMinLifetimeSeconds()),
+		ExcludeIDs:  req.GetExcludeIds(),
+		TTL:         time.Second * time.Duration(req.GetTtlSeconds()),
+		Exclusive:   req.GetExclusive(),
+		Wait:        time.Second * time.Duration(req.GetWaitSeconds()),
+		Strategy:    req.GetStrategy(),
+
+		TargetDomain: req.GetTargetDomain(),
+		TagFilter: domain.TagFilter{
+			AnyTags: req.GetTagsAny(),
+			AllTags: req.GetTagsAll(),
+		},
+	}
+}
+
+func fromProtoListRequest(req *proxyv1.ListProxiesRequest) (domain.ProxyFilter, domain.ProxySort, domain.ProxyPage) {
+	filter := domain.ProxyFilter{
+		TagFilter: domain.TagFilter{
+			AnyTags: req.GetTagsAny(),
+			AllTags: req.GetTagsAll(),
+		},
+		Pool:          req.GetPool(),
+		Enabled:       req.Enabled,
+		Protocol:      req.GetProtocol(),
+		HostContains:  req.GetHost(),
+		Port:          req.GetPort(),
+		ExpiresAfter:  fromProtoTime(req.GetExpiresAfter()),
+		ExpiresBefore: fromProtoTime(req.GetExpiresBefore()),
+		Occupied:      req.Occupied,
+	}
+	sort := domain.ProxySort{Field: req.GetSort(), Desc: req.GetDesc()}
+	page := domain.ProxyPage{
+		Offset:    req.GetOffset(),
+		Cursor:    req.GetCursor(),
+		Limit:     req.GetLimit(),
+		WithTotal: req.GetWithTotal(),
+	}
+	return filter, sort, page
+}
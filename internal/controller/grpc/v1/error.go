@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"proxy_manager/internal/usecase"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorStatus converts usecase error to gRPC status, internal errors are not exposed to client.
+func errorStatus(err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidData):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, usecase.ErrNotFound), errors.Is(err, usecase.ErrPoolNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, usecase.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, usecase.ErrUnauthorized):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, usecase.ErrVersionMismatch):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, usecase.ErrProxiesSaturated):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
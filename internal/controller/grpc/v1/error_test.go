@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"errors"
+	"proxy_manager/internal/usecase"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{err: errors.Join(usecase.ErrInvalidData, errors.New("port must be > 0")), want: codes.InvalidArgument},
+		{err: usecase.ErrNotFound, want: codes.NotFound},
+		{err: usecase.ErrPoolNotFound, want: codes.NotFound},
+		{err: &usecase.DuplicateProxyError{ProxyID: 1}, want: codes.AlreadyExists},
+		{err: usecase.ErrVersionMismatch, want: codes.FailedPrecondition},
+		{err: usecase.ErrProxiesSaturated, want: codes.Unavailable},
+		{err: errors.Join(usecase.ErrInRepo, errors.New("connection refused")), want: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			if got := status.Code(errorStatus(tt.err)); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package v1
+
+import (
+	"context"
+	"proxy_manager/internal/domain"
+	"proxy_manager/internal/usecase"
+	proxyv1 "proxy_manager/pkg/api/proxy/v1"
+	"proxy_manager/pkg/logger"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// logInterceptor logs every call with its status code and duration.
+func logInterceptor(l logger.Interface) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		l.Info("grpc - %s - %s - %s", info.FullMethod, status.Code(err), time.Since(start))
+		return resp, err
+	}
+}
+
+// logStreamInterceptor logs every stream with its status code and duration.
+func logStreamInterceptor(l logger.Interface) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		l.Info("grpc - %s - %s - %s", info.FullMethod, status.Code(err), time.Since(start))
+		return err
+	}
+}
+
+// recoveryInterceptor converts panic in handler to Internal status.
+func recoveryInterceptor(l logger.Interface) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				l.Error("grpc - %s - panic: %v", info.FullMethod, r)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// recoveryStreamInterceptor converts panic in stream handler to Internal status.
+func recoveryStreamInterceptor(l logger.Interface) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				l.Error("grpc - %s - panic: %v", info.FullMethod, r)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// apiKeyContextKey is a context key of authenticated API key.
+type apiKeyContextKey struct{}
+
+// methodPermissions are permissions of API key required to call methods, methods without permission are denied.
+var methodPermissions = map[string]string{
+	proxyv1.ProxyService_CreateProxy_FullMethodName:      domain.PermissionWrite,
+	proxyv1.ProxyService_GetProxy_FullMethodName:         domain.PermissionRead,
+	proxyv1.ProxyService_UpdateProxy_FullMethodName:      domain.PermissionWrite,
+	proxyv1.ProxyService_DeleteProxy_FullMethodName:      domain.PermissionWrite,
+	proxyv1.ProxyService_ListProxies_FullMethodName:      domain.PermissionRead,
+	proxyv1.ProxyService_StreamProxies_FullMethodName:    domain.PermissionRead,
+	proxyv1.ProxyService_OccupyProxy_FullMethodName:      domain.PermissionOccupy,
+	proxyv1.ProxyService_ReleaseProxy_FullMethodName:     domain.PermissionOccupy,
+	proxyv1.ProxyService_RenewProxyOccupy_FullMethodName: domain.PermissionOccupy,
+}
+
+// authInterceptor authenticates call by API key token in "authorization: Bearer <token>" metadata
+// and checks that its role allows to call method.
+func authInterceptor(auth usecase.Auth, l logger.Interface) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, auth, l, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authStreamInterceptor authenticates stream as authInterceptor does.
+func authStreamInterceptor(auth usecase.Auth, l logger.Interface) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), auth, l, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, authorizedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorizedStream is a stream with context, that holds authenticated API key.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authorize returns ctx with API key, that is authenticated by token in metadata and allowed to call method.
+func authorize(ctx context.Context, auth usecase.Auth, l logger.Interface, method string) (context.Context, error) {
+	var token string
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		if bearer, ok := strings.CutPrefix(values[0], "Bearer "); ok {
+			token = bearer
+		}
+	}
+
+	key, err := auth.Authenticate(ctx, strings.TrimSpace(token))
+	if err != nil {
+		l.Error("grpc - %s - %s", method, err)
+		return nil, errorStatus(err)
+	}
+
+	permission, ok := methodPermissions[method]
+	if !ok || !key.Can(permission) {
+		return nil, status.Errorf(codes.PermissionDenied, "api key role %s doesn't allow to call %s", key.Role, method)
+	}
+	return context.WithValue(ctx, apiKeyContextKey{}, key), nil
+}
+
+// credentialsRequest is a request, that may ask for proxy passwords.
+type credentialsRequest interface {
+	GetIncludeCredentials() bool
+}
+
+// includeCredentials reports whether proxy passwords are returned, they are hidden unless request asks for them,
+// and only API keys with write permission are allowed to ask.
+func includeCredentials(ctx context.Context, req credentialsRequest) (bool, error) {
+	if !req.GetIncludeCredentials() {
+		return false, nil
+	}
+
+	key, _ := ctx.Value(apiKeyContextKey{}).(domain.APIKey)
+	if !key.Can(domain.PermissionWrite) {
+		return false, status.Errorf(codes.PermissionDenied, "api key role %s doesn't allow to include credentials", key.Role)
+	}
+	return true, nil
+}
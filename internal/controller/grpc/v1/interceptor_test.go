@@ -0,0 +1,244 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"proxy_manager/internal/domain"
+	"proxy_manager/internal/usecase"
+	proxyv1 "proxy_manager/pkg/api/proxy/v1"
+	"proxy_manager/pkg/logger"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// memoryAPIKeyRepo keeps API keys in memory.
+type memoryAPIKeyRepo struct {
+	domain.APIKeyRepository
+	keys []domain.APIKey
+}
+
+func (r *memoryAPIKeyRepo) CreateAPIKey(_ context.Context, key domain.APIKey) (domain.APIKey, error) {
+	key.ID = int64(len(r.keys) + 1)
+	r.keys = append(r.keys, key)
+	return key, nil
+}
+
+func (r *memoryAPIKeyRepo) GetActiveAPIKey(_ context.Context, hash []byte) (domain.APIKey, error) {
+	for _, key := range r.keys {
+		if bytes.Equal(key.Hash, hash) {
+			return key, nil
+		}
+	}
+	return domain.APIKey{}, usecase.ErrAPIKeyNotFound
+}
+
+// newTestAuth returns enabled auth and tokens of API keys by their roles.
+func newTestAuth(t *testing.T) (usecase.Auth, map[string]string) {
+	auth := usecase.NewAuth(&memoryAPIKeyRepo{}, true, "")
+	tokens := make(map[string]string)
+	for _, role := range []string{domain.RoleAdmin, domain.RoleConsumer, domain.RoleReadOnly} {
+		_, token, err := auth.CreateAPIKey(context.Background(), role, role)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tokens[role] = token
+	}
+	return auth, tokens
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestMethodPermissions(t *testing.T) {
+	var methods []string
+	for _, method := range proxyv1.ProxyService_ServiceDesc.Methods {
+		methods = append(methods, method.MethodName)
+	}
+	for _, stream := range proxyv1.ProxyService_ServiceDesc.Streams {
+		methods = append(methods, stream.StreamName)
+	}
+
+	for _, method := range methods {
+		fullMethod := "/" + proxyv1.ProxyService_ServiceDesc.ServiceName + "/" + method
+		if _, ok := methodPermissions[fullMethod]; !ok {
+			t.Errorf("method %s has no permission, so it is always denied", fullMethod)
+		}
+	}
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	auth, tokens := newTestAuth(t)
+	interceptor := authInterceptor(auth, logger.NewTestLogger(t))
+
+	tests := []struct {
+		name   string
+		token  string
+		method string
+		want   codes.Code
+	}{
+		{name: "no token", method: proxyv1.ProxyService_GetProxy_FullMethodName, want: codes.Unauthenticated},
+		{name: "unknown token", token: "pm_unknown", method: proxyv1.ProxyService_GetProxy_FullMethodName, want: codes.Unauthenticated},
+		{name: "admin writes", token: tokens[domain.RoleAdmin], method: proxyv1.ProxyService_CreateProxy_FullMethodName, want: codes.OK},
+		{name: "read only reads", token: tokens[domain.RoleReadOnly], method: proxyv1.ProxyService_ListProxies_FullMethodName, want: codes.OK},
+		{name: "read only writes", token: tokens[domain.RoleReadOnly], method: proxyv1.ProxyService_DeleteProxy_FullMethodName, want: codes.PermissionDenied},
+		{name: "read only occupies", token: tokens[domain.RoleReadOnly], method: proxyv1.ProxyService_OccupyProxy_FullMethodName, want: codes.PermissionDenied},
+		{name: "consumer occupies", token: tokens[domain.RoleConsumer], method: proxyv1.ProxyService_OccupyProxy_FullMethodName, want: codes.OK},
+		{name: "consumer reads", token: tokens[domain.RoleConsumer], method: proxyv1.ProxyService_GetProxy_FullMethodName, want: codes.PermissionDenied},
+		{name: "unknown method", token: tokens[domain.RoleAdmin], method: "/proxy_manager.proxy.v1.ProxyService/Unknown", want: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = withToken(ctx, tt.token)
+			}
+
+			var key domain.APIKey
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req any) (any, error) {
+				key, _ = ctx.Value(apiKeyContextKey{}).(domain.APIKey)
+				return nil, nil
+			})
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+			if tt.want == codes.OK && key.Role == "" {
+				t.Fatal("API key is not passed to handler")
+			}
+		})
+	}
+}
+
+func TestIncludeCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		include bool
+		want    bool
+		code    codes.Code
+	}{
+		{name: "not requested", role: domain.RoleReadOnly},
+		{name: "admin", role: domain.RoleAdmin, include: true, want: true},
+		{name: "read only", role: domain.RoleReadOnly, include: true, code: codes.PermissionDenied},
+		{name: "consumer", role: domain.RoleConsumer, include: true, code: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), apiKeyContextKey{}, domain.APIKey{Role: tt.role})
+			got, err := includeCredentials(ctx, &proxyv1.GetProxyRequest{IncludeCredentials: tt.include})
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("expected %s, got %s", tt.code, code)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
+
+// pagedProxyRepo returns proxies sorted by id in pages of pageSize.
+type pagedProxyRepo struct {
+	domain.ProxyRepository
+	proxies  []domain.Proxy
+	pageSize int
+}
+
+func (r *pagedProxyRepo) GetProxyList(_ context.Context, _ domain.ProxyFilter, sort domain.ProxySort, page domain.ProxyPage) (domain.ProxyList, error) {
+	var afterID int64
+	if page.Cursor != "" {
+		cursor, err := domain.DecodeProxyCursor(page.Cursor, sort)
+		if err != nil {
+			return domain.ProxyList{}, err
+		}
+		afterID = cursor.ID
+	}
+
+	var proxyList domain.ProxyList
+	for _, proxy := range r.proxies {
+		if proxy.ID <= afterID {
+			continue
+		}
+		if len(proxyList.Proxies) == r.pageSize {
+			proxyList.NextCursor = domain.NewProxyCursor(sort, proxyList.Proxies[r.pageSize-1]).Encode()
+			break
+		}
+		proxyList.Proxies = append(proxyList.Proxies, proxy)
+	}
+	return proxyList, nil
+}
+
+func TestStreamProxies(t *testing.T) {
+	repo := &pagedProxyRepo{pageSize: 2}
+	for id := int64(1); id <= 5; id++ {
+		repo.proxies = append(repo.proxies, domain.Proxy{ID: id, Protocol: "http", Host: "127.0.0.1", Port: 8000 + id, Password: "qwerty"})
+	}
+	u := usecase.New(repo, nil, time.Minute, time.Hour, time.Minute, usecase.StrategyLeastOccupied, domain.ProxyScoring{Decay: 0.5}, time.Hour)
+	auth, tokens := newTestAuth(t)
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(u, auth, logger.NewTestLogger(t))
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := proxyv1.NewProxyServiceClient(conn)
+
+	recvAll := func(token string) ([]*proxyv1.Proxy, error) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+		stream, err := client.StreamProxies(ctx, &proxyv1.ListProxiesRequest{Limit: 1})
+		if err != nil {
+			return nil, err
+		}
+
+		var proxies []*proxyv1.Proxy
+		for {
+			proxy, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return proxies, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	proxies, err := recvAll(tokens[domain.RoleReadOnly])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proxies) != len(repo.proxies) {
+		t.Fatalf("expected %d proxies, got %d", len(repo.proxies), len(proxies))
+	}
+	for i, proxy := range proxies {
+		if proxy.GetId() != repo.proxies[i].ID {
+			t.Fatalf("expected proxy %d at %d, got %d", repo.proxies[i].ID, i, proxy.GetId())
+		}
+		if proxy.GetPassword() != "" {
+			t.Fatalf("password of proxy %d is not hidden", proxy.GetId())
+		}
+	}
+
+	if _, err := recvAll(tokens[domain.RoleConsumer]); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package v1
+
+import (
+	"context"
+	"proxy_manager/internal/domain"
+	"proxy_manager/internal/usecase"
+	proxyv1 "proxy_manager/pkg/api/proxy/v1"
+	"proxy_manager/pkg/logger"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// defaultListLimit is used for list requests without limit, as in HTTP API.
+const defaultListLimit = 20
+
+type proxyService struct {
+	proxyv1.UnimplementedProxyServiceServer
+
+	u usecase.UseCase
+	l logger.Interface
+}
+
+func (s *proxyService) CreateProxy(ctx context.Context, req *proxyv1.CreateProxyRequest) (*proxyv1.Proxy, error) {
+	if req.GetProxy() == nil {
+		return nil, status.Error(codes.InvalidArgument, "proxy is required")
+	}
+	withCredentials, err := includeCredentials(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := s.u.CreateProxy(ctx, fromProtoProxyData(0, req.GetProxy()))
+	if err != nil {
+		s.l.Error("grpc - v1 - CreateProxy - %s", err)
+		return nil, errorStatus(err)
+	}
+	if !withCredentials {
+		proxy.HideCredentials()
+	}
+	return toProtoProxy(proxy), nil
+}
+
+func (s *proxyService) GetProxy(ctx context.Context, req *proxyv1.GetProxyRequest) (*proxyv1.Proxy, error) {
+	withCredentials, err := includeCredentials(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := s.u.GetProxy(ctx, req.GetId())
+	if err != nil {
+		s.l.Error("grpc - v1 - GetProxy - %s", err)
+		return nil, errorStatus(err)
+	}
+	if !withCredentials {
+		proxy.HideCredentials()
+	}
+	return toProtoProxy(proxy), nil
+}
+
+func (s *proxyService) UpdateProxy(ctx context.Context, req *proxyv1.UpdateProxyRequest) (*proxyv1.Proxy, error) {
+	if req.GetProxy() == nil {
+		return nil, status.Error(codes.InvalidArgument, "proxy is required")
+	}
+	withCredentials, err := includeCredentials(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedProxy := fromProtoProxyData(req.GetId(), req.GetProxy())
+	updatedProxy.Version = req.GetVersion()
+
+	proxy, err := s.u.UpdateProxy(ctx, updatedProxy, req.GetProxy().Password == nil)
+	if err != nil {
+		s.l.Error("grpc - v1 - UpdateProxy - %s", err)
+		return nil, errorStatus(err)
+	}
+	if !withCredentials {
+		proxy.HideCredentials()
+	}
+	return toProtoProxy(proxy), nil
+}
+
+func (s *proxyService) DeleteProxy(ctx context.Context, req *proxyv1.DeleteProxyRequest) (*emptypb.Empty, error) {
+	if err := s.u.DeleteProxy(ctx, req.GetId(), req.GetVersion()); err != nil {
+		s.l.Error("grpc - v1 - DeleteProxy - %s", err)
+		return nil, errorStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *proxyService) ListProxies(ctx context.Context, req *proxyv1.ListProxiesRequest) (*proxyv1.ListProxiesResponse, error) {
+	withCredentials, err := includeCredentials(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, sort, page := fromProtoListRequest(req)
+	if page.Limit == 0 {
+		page.Limit = defaultListLimit
+	}
+
+	proxyList, err := s.u.GetProxyList(ctx, filter, sort, page)
+	if err != nil {
+		s.l.Error("grpc - v1 - ListProxies - %s", err)
+		return nil, errorStatus(err)
+	}
+
+	resp := &proxyv1.ListProxiesResponse{
+		Proxies:    make([]*proxyv1.Proxy, 0, len(proxyList.Proxies)),
+		Offset:     proxyList.Offset,
+		Total:      proxyList.Total,
+		NextCursor: proxyList.NextCursor,
+	}
+	for _, proxy := range proxyList.Proxies {
+		if !withCredentials {
+			proxy.HideCredentials()
+		}
+		resp.Proxies = append(resp.Proxies, toProtoProxy(proxy))
+	}
+	return resp, nil
+}
+
+// StreamProxies pages through proxy list by cursor, so proxies are not skipped, even if list is changed meanwhile.
+func (s *proxyService) StreamProxies(req *proxyv1.ListProxiesRequest, stream proxyv1.ProxyService_StreamProxiesServer) error {
+	ctx := stream.Context()
+	withCredentials, err := includeCredentials(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	filter, sort, _ := fromProtoListRequest(req)
+	page := domain.ProxyPage{Limit: domain.ExportPageSize}
+	for {
+		proxyList, err := s.u.GetProxyList(ctx, filter, sort, page)
+		if err != nil {
+			s.l.Error("grpc - v1 - StreamProxies - %s", err)
+			return errorStatus(err)
+		}
+
+		for _, proxy := range proxyList.Proxies {
+			if !withCredentials {
+				proxy.HideCredentials()
+			}
+			if err := stream.Send(toProtoProxy(proxy)); err != nil {
+				return err
+			}
+		}
+		if proxyList.NextCursor == "" {
+			return nil
+		}
+		page.Cursor = proxyList.NextCursor
+	}
+}
+
+func (s *proxyService) OccupyProxy(ctx context.Context, req *proxyv1.OccupyProxyRequest) (*proxyv1.ProxyOccupy, error) {
+	var (
+		proxyOccupy domain.ProxyOccupy
+		err         error
+	)
+	if req.GetPool() != "" {
+		proxyOccupy, err = s.u.OccupyPoolProxy(ctx, req.GetPool(), fromProtoOccupyRequest(req))
+	} else {
+		proxyOccupy, err = s.u.OccupyMostAvailableProxy(ctx, fromProtoOccupyRequest(req))
+	}
+	if err != nil {
+		s.l.Error("grpc - v1 - OccupyProxy - %s", err)
+		return nil, errorStatus(err)
+	}
+	return toProtoProxyOccupy(proxyOccupy), nil
+}
+
+func (s *proxyService) ReleaseProxy(ctx context.Context, req *proxyv1.ReleaseProxyRequest) (*emptypb.Empty, error) {
+	var outcome *domain.ProxyOutcome
+	if o := req.GetOutcome(); o != nil {
+		outcome = &domain.ProxyOutcome{
+			Status:  o.GetStatus(),
+			Reason:  o.GetReason(),
+			Bytes:   o.GetBytes(),
+			Latency: time.Millisecond * time.Duration(o.GetLatency()),
+		}
+	}
+
+	if err := s.u.ReleaseProxy(ctx, req.GetKey(), outcome); err != nil {
+		s.l.Error("grpc - v1 - ReleaseProxy - %s", err)
+		return nil, errorStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *proxyService) RenewProxyOccupy(ctx context.Context, req *proxyv1.RenewProxyOccupyRequest) (*proxyv1.ProxyOccupy, error) {
+	proxyOccupy, err := s.u.RenewProxyOccupy(ctx, req.GetKey())
+	if err != nil {
+		s.l.Error("grpc - v1 - RenewProxyOccupy - %s", err)
+		return nil, errorStatus(err)
+	}
+	return toProtoProxyOccupy(proxyOccupy), nil
+}
@@ -0,0 +1,19 @@
+package v1
+
+import (
+	"proxy_manager/internal/usecase"
+	proxyv1 "proxy_manager/pkg/api/proxy/v1"
+	"proxy_manager/pkg/logger"
+
+	"google.golang.org/grpc"
+)
+
+// NewServer creates gRPC server with services sharing usecase and authentication with HTTP API.
+func NewServer(u usecase.UseCase, auth usecase.Auth, l logger.Interface) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(recoveryInterceptor(l), logInterceptor(l), authInterceptor(auth, l)),
+		grpc.ChainStreamInterceptor(recoveryStreamInterceptor(l), logStreamInterceptor(l), authStreamInterceptor(auth, l)),
+	)
+	proxyv1.RegisterProxyServiceServer(server, &proxyService{u: u, l: l})
+	return server
+}
@@ -0,0 +1,141 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"proxy_manager/internal/domain"
+	"proxy_manager/internal/usecase"
+	"proxy_manager/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyRoutes struct {
+	auth usecase.Auth
+	l    logger.Interface
+}
+
+func newAPIKeyRoutes(handler *gin.RouterGroup, auth usecase.Auth, l logger.Interface) {
+	r := &APIKeyRoutes{auth: auth, l: l}
+
+	write := requirePermission(domain.PermissionWrite)
+
+	handler.POST("/api-keys", write, r.createAPIKey)
+	handler.GET("/api-keys", write, r.getAPIKeyList)
+	handler.DELETE("/api-keys/:keyID", write, r.revokeAPIKey)
+}
+
+type createAPIKeyRequest struct {
+	Name string `json:"name" binding:"required" example:"scraper-eu" extensions:"x-order=1"`
+	Role string `json:"role" binding:"required" example:"consumer"   extensions:"x-order=2" enums:"admin,consumer,read_only"`
+}
+
+type createAPIKeyResponse struct {
+	APIKey domain.APIKey `json:"api_key" extensions:"x-order=1"`
+	Token  string        `json:"token"   extensions:"x-order=2" example:"pm_3q2f..."` // shown only once
+}
+
+// createAPIKey godoc
+//
+//	@Summary		Create API key
+//	@Description	Creates API key with given role and returns its token, which is stored hashed and can't be shown again.
+//	@Description	admin has full access, consumer can only occupy, renew, ban and release proxies, read_only can only read proxies and pools.
+//	@Tags			api-keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		createAPIKeyRequest	true	"Create API key"
+//	@Success		200		{object}	createAPIKeyResponse
+//	@Failure		400		{object}	errResponse
+//	@Failure		401		{object}	errResponse
+//	@Failure		403		{object}	errResponse
+//	@Failure		500		{object}	errResponse
+//	@Security		BearerAuth
+//	@Router			/api-keys [POST]
+func (u *APIKeyRoutes) createAPIKey(c *gin.Context) {
+	var req createAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		u.l.Error("http - v1 - createAPIKey - %s", err)
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	key, token, err := u.auth.CreateAPIKey(c, req.Name, req.Role)
+	if err != nil {
+		u.l.Error("http - v1 - createAPIKey - %s", err)
+		if errors.Is(err, usecase.ErrInvalidData) {
+			errorResponse(c, http.StatusBadRequest, err.Error())
+		} else {
+			errorResponse(c, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, createAPIKeyResponse{APIKey: key, Token: token})
+}
+
+// getAPIKeyList godoc
+//
+//	@Summary		Get API key list
+//	@Description	Returns all API keys including revoked ones, without tokens
+//	@Tags			api-keys
+//	@Produce		json
+//	@Success		200	{array}		domain.APIKey
+//	@Failure		401	{object}	errResponse
+//	@Failure		403	{object}	errResponse
+//	@Failure		500	{object}	errResponse
+//	@Security		BearerAuth
+//	@Router			/api-keys [GET]
+func (u *APIKeyRoutes) getAPIKeyList(c *gin.Context) {
+	keys, err := u.auth.GetAPIKeyList(c)
+	if err != nil {
+		u.l.Error("http - v1 - getAPIKeyList - %s", err)
+		errorResponse(c, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	if keys == nil {
+		keys = []domain.APIKey{}
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+type apiKeyIDRequest struct {
+	KeyID int64 `uri:"keyID" binding:"required" example:"3"`
+}
+
+// revokeAPIKey godoc
+//
+//	@Summary		Revoke API key
+//	@Description	Revokes API key with given ID, its token isn't accepted anymore
+//	@Tags			api-keys
+//	@Produce		json
+//	@Param			keyID	path	int64	true	"API key ID"
+//	@Success		204		"No content"
+//	@Failure		400		{object}	errResponse
+//	@Failure		401		{object}	errResponse
+//	@Failure		403		{object}	errResponse
+//	@Failure		404		{object}	errResponse
+//	@Failure		500		{object}	errResponse
+//	@Security		BearerAuth
+//	@Router			/api-keys/{keyID} [DELETE]
+func (u *APIKeyRoutes) revokeAPIKey(c *gin.Context) {
+	var req apiKeyIDRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		u.l.Error("http - v1 - revokeAPIKey - %s", err)
+		errorResponse(c, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	if err := u.auth.RevokeAPIKey(c, req.KeyID); err != nil {
+		u.l.Error("http - v1 - revokeAPIKey - %s", err)
+		if errors.Is(err, usecase.ErrAPIKeyNotFound) {
+			errorResponse(c, http.StatusNotFound, "api key not found")
+		} else if errors.Is(err, usecase.ErrInvalidData) {
+			errorResponse(c, http.StatusBadRequest, err.Error())
+		} else {
+			errorResponse(c, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -2,7 +2,13 @@ package v1
 
 import (
 	"encoding/json"
+	"errors"
+	"net/http"
+	"proxy_manager/internal/domain"
+	"proxy_manager/internal/usecase"
 	"proxy_manager/pkg/logger"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -33,3 +39,77 @@ func JSONLogMiddleware(l logger.Interface) gin.HandlerFunc {
 		}
 	}
 }
+
+// apiKeyContextKey is a key of authenticated domain.APIKey in gin context.
+const apiKeyContextKey = "api_key"
+
+// AuthMiddleware authenticates request by API key token in "Authorization: Bearer <token>" header.
+func AuthMiddleware(auth usecase.Auth, l logger.Interface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok {
+			token = ""
+		}
+
+		key, err := auth.Authenticate(c, strings.TrimSpace(token))
+		if err != nil {
+			l.Error("http - v1 - AuthMiddleware - %s", err)
+			if errors.Is(err, usecase.ErrUnauthorized) {
+				c.Header("WWW-Authenticate", "Bearer")
+				errorResponse(c, http.StatusUnauthorized, err.Error())
+			} else {
+				errorResponse(c, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		c.Set(apiKeyContextKey, key)
+		c.Next()
+	}
+}
+
+// requirePermission responds with 403, if API key of request doesn't have permission.
+func requirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, _ := c.MustGet(apiKeyContextKey).(domain.APIKey)
+		if !key.Can(permission) {
+			errorResponse(c, http.StatusForbidden, "api key role "+key.Role+" doesn't allow to "+permission)
+			return
+		}
+		c.Next()
+	}
+}
+
+// includeCredentialsContextKey marks requests, that asked for proxy credentials and are allowed to see them.
+const includeCredentialsContextKey = "include_credentials"
+
+// credentialsAccess handles include_credentials query param, proxy passwords are hidden in response
+// unless it is set, and only API keys with write permission are allowed to set it.
+func credentialsAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		include := false
+		if value := c.Query("include_credentials"); value != "" {
+			var err error
+			if include, err = strconv.ParseBool(value); err != nil {
+				errorResponse(c, http.StatusBadRequest, "include_credentials must be boolean")
+				return
+			}
+		}
+
+		key, _ := c.MustGet(apiKeyContextKey).(domain.APIKey)
+		if include && !key.Can(domain.PermissionWrite) {
+			errorResponse(c, http.StatusForbidden, "api key role "+key.Role+" doesn't allow to include credentials")
+			return
+		}
+
+		c.Set(includeCredentialsContextKey, include)
+		c.Next()
+	}
+}
+
+// hideCredentials hides password of proxy in response, unless request included credentials.
+func hideCredentials(c *gin.Context, proxy *domain.Proxy) {
+	if !c.GetBool(includeCredentialsContextKey) {
+		proxy.HideCredentials()
+	}
+}
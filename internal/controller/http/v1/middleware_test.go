@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"proxy_manager/internal/domain"
+	"proxy_manager/internal/usecase"
+	"proxy_manager/pkg/logger"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := gin.New()
+	api := handler.Group("", AuthMiddleware(usecase.NewAuth(nil, true, "admin-token"), logger.NewTestLogger(t)))
+	api.GET("/read", requirePermission(domain.PermissionRead), func(c *gin.Context) { c.Status(http.StatusOK) })
+	api.GET("/occupy", requirePermission(domain.PermissionOccupy), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		path          string
+		authorization string
+		want          int
+	}{
+		{path: "/read", authorization: "", want: http.StatusUnauthorized},
+		{path: "/read", authorization: "Bearer wrong-token", want: http.StatusUnauthorized},
+		{path: "/read", authorization: "admin-token", want: http.StatusUnauthorized},
+		{path: "/read", authorization: "Bearer admin-token", want: http.StatusOK},
+		{path: "/occupy", authorization: "Bearer admin-token", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path+" "+tt.authorization, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Authorization", tt.authorization)
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d %s", tt.want, w.Code, w.Body)
+			}
+			if w.Code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Fatal("expected WWW-Authenticate header")
+			}
+		})
+	}
+
+	for role, want := range map[string]int{domain.RoleAdmin: http.StatusOK, domain.RoleConsumer: http.StatusForbidden, domain.RoleReadOnly: http.StatusOK} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set(apiKeyContextKey, domain.APIKey{Role: role})
+		requirePermission(domain.PermissionRead)(c)
+		if c.IsAborted() != (want == http.StatusForbidden) || w.Code != want {
+			t.Fatalf("role %s: expected %d, got %d", role, want, w.Code)
+		}
+	}
+}
+
+func TestCredentialsAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		role  string
+		query string
+		want  int
+	}{
+		{role: domain.RoleReadOnly, query: "", want: http.StatusOK},
+		{role: domain.RoleReadOnly, query: "include_credentials=false", want: http.StatusOK},
+		{role: domain.RoleReadOnly, query: "include_credentials=true", want: http.StatusForbidden},
+		{role: domain.RoleAdmin, query: "include_credentials=true", want: http.StatusOK},
+		{role: domain.RoleAdmin, query: "include_credentials=yes", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role+" "+tt.query, func(t *testing.T) {
+			handler := gin.New()
+			handler.GET("/proxies/1", func(c *gin.Context) {
+				c.Set(apiKeyContextKey, domain.APIKey{Role: tt.role})
+			}, credentialsAccess(), func(c *gin.Context) {
+				proxy := domain.Proxy{ID: 1, Username: "login123", Password: "qwerty"}
+				hideCredentials(c, &proxy)
+				c.JSON(http.StatusOK, proxy)
+			})
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/proxies/1?"+tt.query, nil))
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d %s", tt.want, w.Code, w.Body)
+			}
+			if w.Code == http.StatusOK && strings.Contains(w.Body.String(), "qwerty") != (tt.query == "include_credentials=true") {
+				t.Fatalf("unexpected credentials in %s", w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"proxy_manager/internal/domain"
+	"proxy_manager/internal/usecase"
+	"proxy_manager/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PoolRoutes struct {
+	u usecase.UseCase
+	l logger.Interface
+}
+
+func newPoolRoutes(handler *gin.RouterGroup, u usecase.UseCase, l logger.Interface) {
+	r := &PoolRoutes{u: u, l: l}
+
+	read := requirePermission(domain.PermissionRead)
+	write := requirePermission(domain.PermissionWrite)
+	occupy := requirePermission(domain.PermissionOccupy)
+
+	handler.POST("/pools", write, r.createPool)
+	handler.GET("/pools", read, r.getPoolList)
+	handler.GET("/pools/:pool", read, r.getPool)
+	handler.PUT("/pools/:pool", write, r.updatePool)
+	handler.DELETE("/pools/:pool", write, r.deletePool)
+
+	handler.POST("/pools/:pool/proxies", write, r.addPoolProxies)
+	handler.POST("/pools/:pool/proxies/remove", write, r.removePoolProxies)
+
+	handler.POST("/pools/:pool/occupy", occupy, r.occupyPoolProxy)
+}
+
+type poolSettings struct {
+	OccupyTTL   int64  `json:"occupy_ttl"   example:"600"            extensions:"x-order=2"` // seconds, server default if zero
+	Strategy    string `json:"strategy"     example:"least_occupied" extensions:"x-order=3" enums:"least_occupied,round_robin,least_recently_used,random,weighted_random,lowest_latency,best_score"`
+	MaxOccupies int64  `json:"max_occupies" example:"3"              extensions:"x-order=4"` // for proxies without own max_occupies, 0 means unlimited
+}
+
+type createPoolRequest struct {
+	Name string `json:"name" binding:"required" example:"team-a" extensions:"x-order=1"`
+	poolSettings
+}
+
+type poolNameRequest struct {
+	Name string `uri:"pool" binding:"required" example:"team-a"`
+}
+
+// createPool godoc
+//
+//	@Summary		Create pool
+//	@Description	Creates named pool of proxies with its own occupy settings
+//	@Tags			pools
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		createPoolRequest	true	"Create pool"
+//	@Success		200		{object}	domain.Pool
+//	@Failure		400		{object}	errResponse
+//	@Failure		401		{object}	errResponse
+//	@Failure		403		{object}	errResponse
+//	@Failure		409		{object}	errResponse
+//	@Failure		500		{object}	errResponse
+//	@Security		BearerAuth
+//	@Router			/pools [POST]
+func (u *PoolRoutes) createPool(c *gin.Context) {
+	var req createPoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		u.l.Error("http - v1 - createPool - %s", err)
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	pool, err := u.u.CreatePool(c, domain.Pool{
+		Name:        req.Name,
+		OccupyTTL:   req.OccupyTTL,
+		Strategy:    req.Strategy,
+		MaxOccupies: req.MaxOccupies,
+	})
+	if err != nil {
+		u.l.Error("http - v1 - createPool - %s", err)
+		if errors.Is(err, usecase.ErrInvalidData) {
+			errorResponse(c, http.StatusBadRequest, err.Error())
+		} else if errors.Is(err, usecase.ErrAlreadyExists) {
+			errorResponse(c, http.StatusConflict, "pool with such name already exists")
+		} else {
+			errorResponse(c, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, pool)
+}
+
+// getPoolList godoc
+//
+//	@Summary		Get pool list
+//	@Description	Returns all pools ordered by name
+//	@Tags			pools
+//	@Produce		json
+//	@Success		200	{array}		domain.Pool
+//	@Failure		401	{object}	errResponse
+//	@Failure		403	{object}	errResponse
+//	@Failure		500	{object}	errResponse
+//	@Security		BearerAuth
+//	@Router			/pools [GET]
+func (u *PoolRoutes) getPoolList(c *gin.Context) {
+	pools, err := u.u.GetPoolList(c)
+	if err != nil {
+		u.l.Error("http - v1 - getPoolList - %s", err)
+		errorResponse(c, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	if pools == nil {
+		pools = []domain.Pool{}
+	}
+	c.JSON(http.StatusOK, pools)
+}
+
+// getPool godoc
+//
+//	@Summary		Get pool
+//	@Description	Returns pool with given name
+//	@Tags			pools
+//	@Produce		json
+//	@Param			pool	path		string	true	"Pool name"
+//	@Success		200		{object}	domain.Pool
+//	@Failure		400		{object}	errResponse
+//	@Failure		401		{object}	errResponse
+//	@Failure		403		{object}	errResponse
+//	@Failure		404		{object}	errResponse
+//	@Failure		500		{object}	errResponse
+//	@Security		BearerAuth
+//	@Router			/pools/{pool} [GET]
+func (u *PoolRoutes) getPool(c *gin.Context) {
+	var uri poolNameRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		u.l.Error("http - v1 - getPool - %s", err)
+		errorResponse(c, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	pool, err := u.u.GetPool(c, uri.Name)
+	if err != nil {
+		u.l.Error("http - v1 - getPool - %s", err)
+		u.poolErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pool)
+}
+
+// updatePool godoc
+//
+//	@Summary		Update pool
+//	@Description	Replaces settings of pool with given name
+//	@Tags			pools
+//	@Accept			json
+//	@Produce		json
+//	@Param			pool	path		string			true	"Pool name"
+//	@Param			request	body		poolSettings	true	"Pool settings"
+//	@Success		200		{object}	domain.Pool
+//	@Failure		400		{object}	errResponse
+//	@Failure		401		{object}	errResponse
+//	@Failure		403		{object}	errResponse
+//	@Failure		404		{object}	errResponse
+//	@Failure		500		{object}	errResponse
+//	@Security		BearerAuth
+//	@Router			/pools/{pool} [PUT]
+func (u *PoolRoutes) updatePool(c *gin.Context) {
+	var uri poolNameRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		u.l.Error("http - v1 - updatePool - %s", err)
+		errorResponse(c, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	var req poolSettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		u.l.Error("http - v1 - updatePool - %s", err)
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	pool, err := u.u.UpdatePool(c, domain.Pool{
+		Name:        uri.Name,
+		OccupyTTL:   req.OccupyTTL,
+		Strategy:    req.Strategy,
+		MaxOccupies: req.MaxOccupies,
+	})
+	if err != nil {
+		u.l.Error("http - v1 - updatePool - %s", err)
+		u.poolErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pool)
+}
+
+// deletePool godoc
+//
+//	@Summary		Delete pool
+//	@Description	Deletes pool with given name, its proxies are not deleted
+//	@Tags			pools
+//	@Produce		json
+//	@Param			pool	path	string	true	"Pool name"
+//	@Success		204		"No content"
+//	@Failure		400		{object}	errResponse
+//	@Failure		401		{object}	errResponse
+//	@Failure		403		{object}	errResponse
+//	@Failure		404		{object}	errResponse
+//	@Failure		500		{object}	errResponse
+//	@Security		BearerAuth
+//	@Router			/pools/{pool} [DELETE]
+func (u *PoolRoutes) deletePool(c *gin.Context) {
+	var uri poolNameRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		u.l.Error("http - v1 - deletePool - %s", err)
+		errorResponse(c, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	if err := u.u.DeletePool(c, uri.Name); err != nil {
+		u.l.Error("http - v1 - deletePool - %s", err)
+		u.poolErrorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+type poolProxiesRequest struct {
+	ProxyIDs []int64 `json:"proxy_ids" binding:"required" example:"1,2,3"`
+}
+
+// addPoolProxies godoc
+//
+//	@Summary		Add proxies to pool
+//	@Description	Adds proxies with given IDs to pool, proxy can belong to several pools
+//	@Tags			pools
+//	@Accept			json
+//	@Produce		json
+//	@Param			pool	path	string				true	"Pool name"
+//	@Param			request	body	poolProxiesRequest	true	"Proxy IDs"
+//	@Success		204		"No content"
+//	@Failure		400		{object}	errResponse
+//	@Failure		401		{object}	errResponse
+//	@Failure		403		{object}	errResponse
+//	@Failure		404		{object}	errResponse
+//	@Failure		500		{object}	errResponse
+//	@Security		BearerAuth
+//	@Router			/pools/{pool}/proxies [POST]
+func (u *PoolRoutes) addPoolProxies(c *gin.Context) {
+	var uri poolNameRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		u.l.Error("http - v1 - addPoolProxies - %s", err)
+		errorResponse(c, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	var req poolProxiesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		u.l.Error("http - v1 - addPoolProxies - %s", err)
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := u.u.AddPoolProxies(c, uri.Name, req.ProxyIDs); err != nil {
+		u.l.Error("http - v1 - addPoolProxies - %s", err)
+		u.poolErrorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// removePoolProxies godoc
+//
+//	@Summary		Remove proxies from pool
+//	@Description	Removes proxies with given IDs from pool, proxies themselves are not deleted
+//	@Tags			pools
+//	@Accept			json
+//	@Produce		json
+//	@Param			pool	path	string				true	"Pool name"
+//	@Param			request	body	poolProxiesRequest	true	"Proxy IDs"
+//	@Success		204		"No content"
+//	@Failure		400		{object}	errResponse
+//	@Failure		401		{object}	errResponse
+//	@Failure		403		{object}	errResponse
+//	@Failure		404		{object}	errResponse
+//	@Failure		500		{object}	errResponse
+//	@Security		BearerAuth
+//	@Router			/pools/{pool}/proxies/remove [POST]
+func (u *PoolRoutes) removePoolProxies(c *gin.Context) {
+	var uri poolNameRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		u.l.Error("http - v1 - removePoolProxies - %s", err)
+		errorResponse(c, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	var req poolProxiesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		u.l.Error("http - v1 - removePoolProxies - %s", err)
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := u.u.RemovePoolProxies(c, uri.Name, req.ProxyIDs); err != nil {
+		u.l.Error("http - v1 - removePoolProxies - %s", err)
+		u.poolErrorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// occupyPoolProxy godoc
+//
+//	@Summary		Occupy proxy of pool
+//	@Description	Occupies proxy of pool with given name like POST /proxies/occupy does,
+//	@Description	but pool TTL, strategy and max occupies are used instead of server defaults.
+//	@Tags			pools
+//	@Accept			json
+//	@Produce		json
+//	@Param			pool	path		string				true	"Pool name"
+//	@Param			request	body		occupyProxyRequest	false	"Proxy selector"
+//	@Success		200		{object}	domain.ProxyOccupy
+//	@Failure		400		{object}	errResponse
+//	@Failure		401		{object}	errResponse
+//	@Failure		403		{object}	errResponse
+//	@Failure		404		{object}	errResponse
+//	@Failure		500		{object}	errResponse
+//	@Failure		503		{object}	errResponse
+//	@Security		BearerAuth
+//	@Router			/pools/{pool}/occupy [POST]
+func (u *PoolRoutes) occupyPoolProxy(c *gin.Context) {
+	var uri poolNameRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		u.l.Error("http - v1 - occupyPoolProxy - %s", err)
+		errorResponse(c, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	var req occupyProxyRequest
+	// Body is optional, empty body means "any proxy of pool"
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		u.l.Error("http - v1 - occupyPoolProxy - %s", err)
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	proxyOccupy, err := u.u.OccupyPoolProxy(c, uri.Name, req.toOptions())
+	if err != nil {
+		u.l.Error("http - v1 - occupyPoolProxy - %s", err)
+		if errors.Is(err, usecase.ErrNotFound) {
+			errorResponse(c, http.StatusNotFound, "not found any available proxy")
+		} else if errors.Is(err, usecase.ErrProxiesSaturated) {
+			errorResponse(c, http.StatusServiceUnavailable, "all proxies saturated")
+		} else {
+			u.poolErrorResponse(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, proxyOccupy)
+}
+
+// poolErrorResponse responds with status matching error of pool usecase.
+func (u *PoolRoutes) poolErrorResponse(c *gin.Context, err error) {
+	if errors.Is(err, usecase.ErrInvalidData) {
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	} else if errors.Is(err, usecase.ErrPoolNotFound) {
+		errorResponse(c, http.StatusNotFound, "pool not found")
+	} else {
+		errorResponse(c, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"proxy_manager/internal/domain"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParsePatchProxyRequest(t *testing.T) {
+	patch, err := parsePatchProxyRequest([]byte(`{"port": 3128, "username": null, "tags": null}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxy := patch.Apply(domain.Proxy{Host: "127.0.0.1", Port: 8080, Username: "login123", Password: "qwerty", Tags: []string{"a"}})
+	if proxy.Host != "127.0.0.1" || proxy.Port != 3128 || proxy.Username != "" || proxy.Password != "qwerty" || len(proxy.Tags) != 0 {
+		t.Fatalf("unexpected patched proxy %+v", proxy)
+	}
+
+	for _, body := range []string{`{"host": null}`, `{"unknown": 1}`, `[]`, `{"port": "8080"}`} {
+		if _, err := parsePatchProxyRequest([]byte(body)); err == nil {
+			t.Fatalf("expected error for %s", body)
+		}
+	}
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		ifMatch   string
+		want      int64
+		wantError bool
+	}{
+		{ifMatch: "", want: 0},
+		{ifMatch: "*", want: 0},
+		{ifMatch: `"3"`, want: 3},
+		{ifMatch: "3", wantError: true},
+		{ifMatch: `W/"3"`, wantError: true},
+		{ifMatch: `"3", "4"`, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ifMatch, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPut, "/proxies/1", nil)
+			c.Request.Header.Set("If-Match", tt.ifMatch)
+
+			got, err := ifMatchVersion(c)
+			if tt.wantError {
+				if err == nil {
+					t.Fatalf("expected error, got %d", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("expected %d, got %d, %v", tt.want, got, err)
+			}
+		})
+	}
+}
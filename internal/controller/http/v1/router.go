@@ -17,7 +17,12 @@ import (
 //	@description	Proxy Manager API documentation
 //	@version		1.0
 //	@BasePath		/api/v1
-func NewRouter(handler *gin.Engine, u usecase.UseCase, l logger.Interface, serveSwag bool) {
+//
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+//	@description				API key token with "Bearer " prefix
+func NewRouter(handler *gin.Engine, u usecase.UseCase, auth usecase.Auth, l logger.Interface, serveSwag bool) {
 	handler.Use(JSONLogMiddleware(l))
 	handler.Use(gin.Recovery())
 
@@ -26,6 +31,10 @@ func NewRouter(handler *gin.Engine, u usecase.UseCase, l logger.Interface, serve
 		if serveSwag {
 			h.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 		}
-		newProxyRoutes(h, u, l)
+
+		api := h.Group("", AuthMiddleware(auth, l))
+		newProxyRoutes(api, u, l)
+		newPoolRoutes(api, u, l)
+		newAPIKeyRoutes(api, auth, l)
 	}
 }
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Roles of API keys.
+const (
+	RoleAdmin    = "admin"     // full access
+	RoleConsumer = "consumer"  // occupy, renew, ban and release proxies
+	RoleReadOnly = "read_only" // read proxies and pools
+)
+
+// Permissions granted to API keys by their roles.
+const (
+	PermissionRead   = "read"   // read proxies and pools
+	PermissionOccupy = "occupy" // occupy, renew, ban and release proxies
+	PermissionWrite  = "write"  // change proxies, pools and API keys
+)
+
+var rolePermissions = map[string][]string{
+	RoleAdmin:    {PermissionRead, PermissionOccupy, PermissionWrite},
+	RoleConsumer: {PermissionOccupy},
+	RoleReadOnly: {PermissionRead},
+}
+
+// APIKey is a bearer token of API client, only hash of token is stored.
+type APIKey struct {
+	ID        int64      `json:"api_key_id" db:"api_key_id" extensions:"x-order=1"`
+	Name      string     `json:"name"       db:"name"       extensions:"x-order=2"`
+	Role      string     `json:"role"       db:"role"       extensions:"x-order=3"`
+	Prefix    string     `json:"prefix"     db:"prefix"     extensions:"x-order=4"` // beginning of token to recognize it
+	CreatedAt time.Time  `json:"created_at" db:"created_at" extensions:"x-order=5"`
+	RevokedAt *time.Time `json:"revoked_at" db:"revoked_at" extensions:"x-order=6"`
+	Hash      []byte     `json:"-"          db:"key_hash"`
+}
+
+type APIKeyRepository interface {
+	// CreateAPIKey stores API key with given hash
+	CreateAPIKey(ctx context.Context, key APIKey) (APIKey, error)
+	// GetActiveAPIKey returns not revoked API key with given hash
+	GetActiveAPIKey(ctx context.Context, hash []byte) (APIKey, error)
+	GetAPIKeyList(ctx context.Context) ([]APIKey, error)
+	// RevokeAPIKey revokes API key, revoking of already revoked key is not an error
+	RevokeAPIKey(ctx context.Context, keyID int64) error
+}
+
+// Can reports whether role of API key grants permission.
+func (k *APIKey) Can(permission string) bool {
+	for _, p := range rolePermissions[k.Role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *APIKey) Validate() error {
+	if k.Name == "" || len(k.Name) > 64 {
+		return errors.New("name must be 1-64 characters long")
+	}
+
+	if _, ok := rolePermissions[k.Role]; !ok {
+		return fmt.Errorf("invalid role, allowed roles: (%s, %s, %s)", RoleAdmin, RoleConsumer, RoleReadOnly)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ProxyPage selects page of proxy list, either by offset or by cursor.
+type ProxyPage struct {
+	Offset    int64
+	Cursor    string // ProxyList.NextCursor of previous page, offset is ignored if set
+	Limit     int64
+	WithTotal bool // count proxies matching filter, it is slow on big lists
+}
+
+// ProxyCursor is a position in sorted proxy list, it points to the last proxy of previous page.
+// It is passed to clients as opaque string.
+type ProxyCursor struct {
+	Field          string     `json:"f"`
+	Desc           bool       `json:"d,omitempty"`
+	ID             int64      `json:"i"`
+	ExpirationDate *time.Time `json:"e,omitempty"`
+	OccupiesCount  int64      `json:"o,omitempty"`
+	Latency        int64      `json:"l,omitempty"`
+}
+
+// NewProxyCursor returns cursor pointing to the proxy in list with given sort.
+func NewProxyCursor(sort ProxySort, proxy Proxy) ProxyCursor {
+	c := ProxyCursor{Field: sort.Field, Desc: sort.Desc, ID: proxy.ID}
+	if c.Field == "" {
+		c.Field = SortByID
+	}
+
+	switch c.Field {
+	case SortByExpirationDate:
+		expirationDate := proxy.ExpirationDate
+		c.ExpirationDate = &expirationDate
+	case SortByOccupiesCount:
+		c.OccupiesCount = proxy.OccupiesCount
+	case SortByLatency:
+		c.Latency = proxy.Latency
+	}
+	return c
+}
+
+func (c ProxyCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeProxyCursor decodes cursor and checks that it was made for list with given sort.
+func DecodeProxyCursor(s string, sort ProxySort) (ProxyCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return ProxyCursor{}, errors.New("invalid cursor")
+	}
+
+	var c ProxyCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return ProxyCursor{}, errors.New("invalid cursor")
+	}
+
+	field := sort.Field
+	if field == "" {
+		field = SortByID
+	}
+	if c.Field != field || c.Desc != sort.Desc {
+		return ProxyCursor{}, errors.New("cursor was made for another sort")
+	}
+	if c.Field == SortByExpirationDate && c.ExpirationDate == nil {
+		return ProxyCursor{}, errors.New("invalid cursor")
+	}
+	return c, nil
+}
+
+func (p *ProxyPage) Validate(sort ProxySort) error {
+	if p.Offset < 0 || p.Limit < 0 {
+		return errors.New("offset and limit must be non negative")
+	}
+
+	if p.Cursor != "" {
+		if _, err := DecodeProxyCursor(p.Cursor, sort); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package domain_test
+
+import (
+	"proxy_manager/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestProxyCursor(t *testing.T) {
+	sort := domain.ProxySort{Field: domain.SortByExpirationDate, Desc: true}
+	proxy := domain.Proxy{ID: 42, ExpirationDate: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	encoded := domain.NewProxyCursor(sort, proxy).Encode()
+	cursor, err := domain.DecodeProxyCursor(encoded, sort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cursor.ID != proxy.ID || cursor.ExpirationDate == nil || !cursor.ExpirationDate.Equal(proxy.ExpirationDate) {
+		t.Fatalf("expected cursor of %+v, got %+v", proxy, cursor)
+	}
+
+	if _, err := domain.DecodeProxyCursor(encoded, domain.ProxySort{Field: domain.SortByExpirationDate}); err == nil {
+		t.Fatal("expected error for cursor of another sort")
+	}
+	if _, err := domain.DecodeProxyCursor("not a cursor", sort); err == nil {
+		t.Fatal("expected error for invalid cursor")
+	}
+
+	// Empty sort field means sorting by id
+	encoded = domain.NewProxyCursor(domain.ProxySort{}, proxy).Encode()
+	if _, err := domain.DecodeProxyCursor(encoded, domain.ProxySort{Field: domain.SortByID}); err != nil {
+		t.Fatal(err)
+	}
+}
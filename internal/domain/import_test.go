@@ -0,0 +1,127 @@
+package domain_test
+
+import (
+	"proxy_manager/internal/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseProxyLine(t *testing.T) {
+	tests := []struct {
+		line      string
+		want      domain.Proxy
+		wantError bool
+	}{
+		{
+			line: "127.0.0.1:8080",
+			want: domain.Proxy{Protocol: "http", Host: "127.0.0.1", Port: 8080},
+		},
+		{
+			line: "127.0.0.1:8080:login123:qwe:rty",
+			want: domain.Proxy{Protocol: "http", Host: "127.0.0.1", Port: 8080, Username: "login123", Password: "qwe:rty"},
+		},
+		{
+			line: "login123:qwerty@proxy.example.com:3128",
+			want: domain.Proxy{Protocol: "http", Host: "proxy.example.com", Port: 3128, Username: "login123", Password: "qwerty"},
+		},
+		{
+			line: "SOCKS5://login123:qwerty@[::1]:1080",
+			want: domain.Proxy{Protocol: "socks5", Host: "::1", Port: 1080, Username: "login123", Password: "qwerty"},
+		},
+		{
+			line: "[::1]:1080",
+			want: domain.Proxy{Protocol: "http", Host: "::1", Port: 1080},
+		},
+		{line: "127.0.0.1", wantError: true},
+		{line: "127.0.0.1:8080:login123", wantError: true},
+		{line: "127.0.0.1:70000", wantError: true},
+		{line: "http://127.0.0.1:8080/path", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := domain.ParseProxyLine(tt.line, "http")
+			if tt.wantError {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.want.Weight = domain.DefaultProxyWeight
+			if got.Protocol != tt.want.Protocol || got.Host != tt.want.Host || got.Port != tt.want.Port ||
+				got.Username != tt.want.Username || got.Password != tt.want.Password || got.Weight != tt.want.Weight {
+				t.Fatalf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseProxyList(t *testing.T) {
+	expirationDate := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		format     string
+		input      string
+		wantLines  []int
+		wantErrors []int
+	}{
+		{
+			name:       "text",
+			format:     domain.ImportFormatText,
+			input:      "# provider list\n127.0.0.1:8080\n\nftp://127.0.0.1:21\nlogin:pass@127.0.0.2:8080\n",
+			wantLines:  []int{2, 5},
+			wantErrors: []int{4},
+		},
+		{
+			name:       "csv",
+			format:     domain.ImportFormatCSV,
+			input:      "proxy_id,protocol,host,port,username,password,expiration_date,tags\n1,socks5,127.0.0.1,1080,login,pass,2031-01-01T00:00:00Z,\"a,b\"\n2,,127.0.0.2,port,,,,\n",
+			wantLines:  []int{2},
+			wantErrors: []int{3},
+		},
+		{
+			name:       "json",
+			format:     domain.ImportFormatJSON,
+			input:      `[{"protocol": "https", "host": "127.0.0.1", "port": 443, "tags": ["a"]}, "127.0.0.2:8080", {"host": ""}, 5]`,
+			wantLines:  []int{1, 2},
+			wantErrors: []int{3, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxies, errs, err := domain.ParseProxyList(strings.NewReader(tt.input), domain.ImportOptions{
+				Format:                tt.format,
+				DefaultProtocol:       "http",
+				DefaultExpirationDate: expirationDate,
+				Tags:                  []string{"imported"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(proxies) != len(tt.wantLines) || len(errs) != len(tt.wantErrors) {
+				t.Fatalf("expected %d proxies and %d errors, got %+v and %+v", len(tt.wantLines), len(tt.wantErrors), proxies, errs)
+			}
+			for i, proxy := range proxies {
+				if proxy.Line != tt.wantLines[i] {
+					t.Fatalf("expected proxy from line %d, got %d", tt.wantLines[i], proxy.Line)
+				}
+				if proxy.Proxy.ExpirationDate.IsZero() || len(proxy.Proxy.Tags) == 0 {
+					t.Fatalf("expected defaults to be applied, got %+v", proxy.Proxy)
+				}
+			}
+			for i, e := range errs {
+				if e.Line != tt.wantErrors[i] {
+					t.Fatalf("expected error on line %d, got %+v", tt.wantErrors[i], e)
+				}
+			}
+		})
+	}
+}
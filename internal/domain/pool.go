@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+// Pool is a named set of proxies with its own occupy settings.
+// Zero settings mean server defaults.
+type Pool struct {
+	ID           int64  `json:"pool_id"       db:"pool_id"       extensions:"x-order=1"`
+	Name         string `json:"name"          db:"name"          extensions:"x-order=2"`
+	OccupyTTL    int64  `json:"occupy_ttl"    db:"occupy_ttl"    extensions:"x-order=3"` // seconds, still capped by server max
+	Strategy     string `json:"strategy"      db:"strategy"      extensions:"x-order=4"`
+	MaxOccupies  int64  `json:"max_occupies"  db:"max_occupies"  extensions:"x-order=5"` // for proxies without own max_occupies, 0 means unlimited
+	ProxiesCount int64  `json:"proxies_count" db:"proxies_count" extensions:"x-order=6"`
+}
+
+type PoolRepository interface {
+	CreatePool(ctx context.Context, pool Pool) (Pool, error)
+	GetPool(ctx context.Context, name string) (Pool, error)
+	UpdatePool(ctx context.Context, pool Pool) (Pool, error)
+	DeletePool(ctx context.Context, name string) error
+	GetPoolList(ctx context.Context) ([]Pool, error)
+
+	// AddPoolProxies adds proxies to pool, returns IDs of proxies, that don't exist, without adding anything
+	AddPoolProxies(ctx context.Context, name string, proxyIDs []int64) ([]int64, error)
+	RemovePoolProxies(ctx context.Context, name string, proxyIDs []int64) error
+}
+
+var poolNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,64}$`)
+
+// Validate checks pool fields, except strategy, which names are known by usecase.
+func (p *Pool) Validate() error {
+	if !poolNameRegexp.MatchString(p.Name) {
+		return errors.New("pool name must consist of 1-64 latin letters, digits, '_', '.' or '-'")
+	}
+
+	if p.OccupyTTL < 0 {
+		return errors.New("occupy ttl must be >= 0")
+	}
+
+	if p.MaxOccupies < 0 {
+		return errors.New("max occupies must be >= 0")
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package domain_test
+
+import (
+	"proxy_manager/internal/domain"
+	"strings"
+	"testing"
+)
+
+func TestPool_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		pool      domain.Pool
+		wantError bool
+	}{
+		{name: "name only", pool: domain.Pool{Name: "scraping"}},
+		{name: "settings", pool: domain.Pool{Name: "eu-west_1.residential", OccupyTTL: 60, Strategy: "round_robin", MaxOccupies: 3}},
+		{name: "empty name", pool: domain.Pool{}, wantError: true},
+		{name: "too long name", pool: domain.Pool{Name: strings.Repeat("a", 65)}, wantError: true},
+		{name: "name with space", pool: domain.Pool{Name: "my pool"}, wantError: true},
+		{name: "negative occupy ttl", pool: domain.Pool{Name: "scraping", OccupyTTL: -1}, wantError: true},
+		{name: "negative max occupies", pool: domain.Pool{Name: "scraping", MaxOccupies: -1}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.pool.Validate(); (err != nil) != tt.wantError {
+				t.Fatalf("expected error: %t, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
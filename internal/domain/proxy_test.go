@@ -0,0 +1,235 @@
+package domain_test
+
+import (
+	"proxy_manager/internal/domain"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProxy_Validate(t *testing.T) {
+	valid := domain.Proxy{Protocol: "http", Host: "127.0.0.1", Port: 8080}
+
+	tests := []struct {
+		name      string
+		modify    func(proxy *domain.Proxy)
+		wantError bool
+	}{
+		{name: "valid", modify: func(proxy *domain.Proxy) {}},
+		{name: "max occupies", modify: func(proxy *domain.Proxy) { proxy.MaxOccupies = 3 }},
+		{name: "negative max occupies", modify: func(proxy *domain.Proxy) { proxy.MaxOccupies = -1 }, wantError: true},
+		{name: "invalid protocol", modify: func(proxy *domain.Proxy) { proxy.Protocol = "ftp" }, wantError: true},
+		{name: "empty host", modify: func(proxy *domain.Proxy) { proxy.Host = "" }, wantError: true},
+		{name: "zero port", modify: func(proxy *domain.Proxy) { proxy.Port = 0 }, wantError: true},
+		{name: "tags", modify: func(proxy *domain.Proxy) { proxy.Tags = []string{"provider=acme", "residential"} }},
+		{name: "empty tag", modify: func(proxy *domain.Proxy) { proxy.Tags = []string{""} }, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy := valid
+			tt.modify(&proxy)
+			if err := proxy.Validate(); (err != nil) != tt.wantError {
+				t.Fatalf("expected error: %t, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestOccupyOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      domain.OccupyOptions
+		wantError bool
+	}{
+		{name: "empty", opts: domain.OccupyOptions{}},
+		{name: "ttl", opts: domain.OccupyOptions{TTL: time.Minute}},
+		{name: "negative ttl", opts: domain.OccupyOptions{TTL: -time.Second}, wantError: true},
+		{name: "negative min lifetime", opts: domain.OccupyOptions{MinLifetime: -time.Second}, wantError: true},
+		{name: "invalid protocol", opts: domain.OccupyOptions{Protocol: "ftp"}, wantError: true},
+		{name: "target domain", opts: domain.OccupyOptions{TargetDomain: "example.com"}},
+		{name: "target URL", opts: domain.OccupyOptions{TargetDomain: "https://example.com/"}, wantError: true},
+		{name: "tags", opts: domain.OccupyOptions{TagFilter: domain.TagFilter{AnyTags: []string{"a", "b"}, AllTags: []string{"c"}}}},
+		{name: "invalid tag", opts: domain.OccupyOptions{TagFilter: domain.TagFilter{AllTags: []string{"a,b"}}}, wantError: true},
+		{name: "pool", opts: domain.OccupyOptions{PoolID: 1, DefaultMaxOccupies: 2}},
+		{name: "negative pool ID", opts: domain.OccupyOptions{PoolID: -1}, wantError: true},
+		{name: "negative default max occupies", opts: domain.OccupyOptions{DefaultMaxOccupies: -1}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(); (err != nil) != tt.wantError {
+				t.Fatalf("expected error: %t, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestBatchOccupyOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      domain.BatchOccupyOptions
+		wantError bool
+	}{
+		{name: "one", opts: domain.BatchOccupyOptions{Count: 1}},
+		{name: "max count", opts: domain.BatchOccupyOptions{Count: domain.MaxBatchOccupyCount, DistinctHosts: true, BestEffort: true}},
+		{name: "zero count", opts: domain.BatchOccupyOptions{}, wantError: true},
+		{name: "too many", opts: domain.BatchOccupyOptions{Count: domain.MaxBatchOccupyCount + 1}, wantError: true},
+		{name: "invalid occupy options", opts: domain.BatchOccupyOptions{Count: 1, OccupyOptions: domain.OccupyOptions{TTL: -time.Second}}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(); (err != nil) != tt.wantError {
+				t.Fatalf("expected error: %t, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeDomain(t *testing.T) {
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{domain: "example.com", want: "example.com"},
+		{domain: " Example.COM. ", want: "example.com"},
+		{domain: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			if got := domain.NormalizeDomain(tt.domain); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateDomain(t *testing.T) {
+	tests := []struct {
+		domain    string
+		wantError bool
+	}{
+		{domain: "example.com"},
+		{domain: "sub.example.co.uk"},
+		{domain: "", wantError: true},
+		{domain: "https://example.com", wantError: true},
+		{domain: "example.com/path", wantError: true},
+		{domain: "user@example.com", wantError: true},
+		{domain: "example.com:443", wantError: true},
+		{domain: "example .com", wantError: true},
+		{domain: strings.Repeat("a", 256), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			if err := domain.ValidateDomain(tt.domain); (err != nil) != tt.wantError {
+				t.Fatalf("expected error: %t, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{name: "nil", tags: nil, want: []string{}},
+		{name: "sorted", tags: []string{"b", "a"}, want: []string{"a", "b"}},
+		{name: "spaces and duplicates", tags: []string{" a", "b ", "a"}, want: []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := domain.NormalizeTags(tt.tags)
+			if got == nil || !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTagFilter_Normalize(t *testing.T) {
+	filter := domain.TagFilter{AnyTags: []string{"b", " a"}, AllTags: []string{"c", "c"}}
+	filter.Normalize()
+
+	want := domain.TagFilter{AnyTags: []string{"a", "b"}, AllTags: []string{"c"}}
+	if !reflect.DeepEqual(filter, want) {
+		t.Fatalf("expected %+v, got %+v", want, filter)
+	}
+}
+
+func TestTagFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    domain.TagFilter
+		wantError bool
+	}{
+		{name: "empty", filter: domain.TagFilter{}},
+		{name: "any and all", filter: domain.TagFilter{AnyTags: []string{"a", "b"}, AllTags: []string{"provider=acme"}}},
+		{name: "empty any tag", filter: domain.TagFilter{AnyTags: []string{""}}, wantError: true},
+		{name: "comma in all tag", filter: domain.TagFilter{AllTags: []string{"a,b"}}, wantError: true},
+		{name: "too long tag", filter: domain.TagFilter{AllTags: []string{strings.Repeat("a", 256)}}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.filter.Validate(); (err != nil) != tt.wantError {
+				t.Fatalf("expected error: %t, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestProxyFilter_Validate(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		filter    domain.ProxyFilter
+		wantError bool
+	}{
+		{name: "empty", filter: domain.ProxyFilter{}},
+		{name: "all fields", filter: domain.ProxyFilter{Protocol: "socks5", HostContains: "example", Port: 1080, ExpiresAfter: &now, ExpiresBefore: &later}},
+		{name: "invalid protocol", filter: domain.ProxyFilter{Protocol: "ftp"}, wantError: true},
+		{name: "negative port", filter: domain.ProxyFilter{Port: -1}, wantError: true},
+		{name: "too big port", filter: domain.ProxyFilter{Port: 65536}, wantError: true},
+		{name: "empty expiration range", filter: domain.ProxyFilter{ExpiresAfter: &later, ExpiresBefore: &now}, wantError: true},
+		{name: "invalid tag", filter: domain.ProxyFilter{TagFilter: domain.TagFilter{AnyTags: []string{""}}}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.filter.Validate(); (err != nil) != tt.wantError {
+				t.Fatalf("expected error: %t, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestProxySort_Validate(t *testing.T) {
+	tests := []struct {
+		sort      domain.ProxySort
+		wantError bool
+	}{
+		{sort: domain.ProxySort{}},
+		{sort: domain.ProxySort{Field: domain.SortByID, Desc: true}},
+		{sort: domain.ProxySort{Field: domain.SortByExpirationDate}},
+		{sort: domain.ProxySort{Field: domain.SortByOccupiesCount}},
+		{sort: domain.ProxySort{Field: domain.SortByLatency}},
+		{sort: domain.ProxySort{Field: "host"}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort.Field, func(t *testing.T) {
+			if err := tt.sort.Validate(); (err != nil) != tt.wantError {
+				t.Fatalf("expected error: %t, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"proxy_manager/internal/domain"
+	"time"
+)
+
+// HTTPProxyChecker checks proxies by requesting target URL through them.
+// http and https proxies are used via CONNECT for https targets, socks5 proxies via SOCKS5 handshake
+// with stored credentials.
+type HTTPProxyChecker struct {
+	targetURL string
+	timeout   time.Duration
+	tlsConfig *tls.Config
+}
+
+// NewHTTPProxyChecker creates checker, tlsConfig is used for connections to https proxies and target,
+// nil means default config.
+func NewHTTPProxyChecker(targetURL string, timeout time.Duration, tlsConfig *tls.Config) HTTPProxyChecker {
+	return HTTPProxyChecker{
+		targetURL: targetURL,
+		timeout:   timeout,
+		tlsConfig: tlsConfig,
+	}
+}
+
+// Check requests target URL through proxy and returns time spent to receive response headers.
+func (c HTTPProxyChecker) Check(ctx context.Context, proxy domain.Proxy) (time.Duration, error) {
+	transport := &http.Transport{
+		Proxy:               http.ProxyURL(proxy.URL()),
+		TLSClientConfig:     c.tlsConfig,
+		TLSHandshakeTimeout: c.timeout,
+		DisableKeepAlives:   true,
+	}
+	defer transport.CloseIdleConnections()
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   c.timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.targetURL, http.NoBody)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	latency := time.Since(start)
+
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return latency, nil
+}
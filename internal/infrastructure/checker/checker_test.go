@@ -0,0 +1,314 @@
+package checker_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"proxy_manager/internal/domain"
+	"proxy_manager/internal/infrastructure/checker"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const (
+	testUsername = "login123"
+	testPassword = "qwerty1234"
+)
+
+func TestHTTPProxyChecker_Check(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer target.Close()
+
+	tlsTarget := httptest.NewTLSServer(target.Config.Handler)
+	defer tlsTarget.Close()
+
+	httpProxy := httptest.NewServer(newHTTPProxyHandler())
+	defer httpProxy.Close()
+
+	httpsProxy := httptest.NewTLSServer(newHTTPProxyHandler())
+	defer httpsProxy.Close()
+
+	socksProxy := newSOCKS5Proxy(t)
+	defer socksProxy.Close()
+
+	deadListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadAddr := deadListener.Addr().String()
+	deadListener.Close()
+
+	// All httptest TLS servers share the same certificate
+	tlsConfig := &tls.Config{RootCAs: tlsTarget.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs}
+
+	tests := []struct {
+		name      string
+		target    string
+		proxy     domain.Proxy
+		wantError bool
+	}{
+		{
+			name:   "http proxy, http target",
+			target: target.URL,
+			proxy:  testProxy(t, "http", httpProxy.Listener.Addr().String(), testUsername, testPassword),
+		},
+		{
+			name:   "http proxy, https target via CONNECT",
+			target: tlsTarget.URL,
+			proxy:  testProxy(t, "http", httpProxy.Listener.Addr().String(), testUsername, testPassword),
+		},
+		{
+			name:   "https proxy",
+			target: tlsTarget.URL,
+			proxy:  testProxy(t, "https", httpsProxy.Listener.Addr().String(), testUsername, testPassword),
+		},
+		{
+			name:   "socks5 proxy",
+			target: target.URL,
+			proxy:  testProxy(t, "socks5", socksProxy.Addr().String(), testUsername, testPassword),
+		},
+		{
+			name:      "http proxy, wrong credentials",
+			target:    target.URL,
+			proxy:     testProxy(t, "http", httpProxy.Listener.Addr().String(), testUsername, "wrong"),
+			wantError: true,
+		},
+		{
+			name:      "http proxy, wrong credentials via CONNECT",
+			target:    tlsTarget.URL,
+			proxy:     testProxy(t, "http", httpProxy.Listener.Addr().String(), testUsername, "wrong"),
+			wantError: true,
+		},
+		{
+			name:      "socks5 proxy, wrong credentials",
+			target:    target.URL,
+			proxy:     testProxy(t, "socks5", socksProxy.Addr().String(), testUsername, "wrong"),
+			wantError: true,
+		},
+		{
+			name:      "dead proxy",
+			target:    target.URL,
+			proxy:     testProxy(t, "http", deadAddr, testUsername, testPassword),
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := checker.NewHTTPProxyChecker(tt.target, time.Second*5, tlsConfig)
+
+			latency, err := c.Check(context.Background(), tt.proxy)
+			if tt.wantError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if latency <= 0 {
+				t.Fatalf("expected positive latency, got %s", latency)
+			}
+		})
+	}
+}
+
+func testProxy(t *testing.T, protocol, addr, username, password string) domain.Proxy {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := strconv.ParseInt(portStr, 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return domain.Proxy{
+		Protocol: protocol,
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+	}
+}
+
+// newHTTPProxyHandler returns stand-in of http proxy with basic auth, that supports CONNECT and plain requests.
+func newHTTPProxyHandler() http.Handler {
+	wantAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte(testUsername+":"+testPassword))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Proxy-Authorization") != wantAuth {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+
+		if r.Method != http.MethodConnect {
+			r.RequestURI = ""
+			r.Header.Del("Proxy-Authorization")
+			resp, err := http.DefaultTransport.RoundTrip(r)
+			if err != nil {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			defer resp.Body.Close()
+
+			w.WriteHeader(resp.StatusCode)
+			_, _ = io.Copy(w, resp.Body)
+			return
+		}
+
+		dst, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		src, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			dst.Close()
+			return
+		}
+
+		_, _ = src.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		pipe(src, dst)
+	})
+}
+
+// newSOCKS5Proxy starts stand-in of socks5 proxy with username/password auth, that supports CONNECT command.
+func newSOCKS5Proxy(t *testing.T) net.Listener {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSOCKS5(conn)
+		}
+	}()
+
+	return ln
+}
+
+func serveSOCKS5(conn net.Conn) {
+	r := bufio.NewReader(conn)
+
+	// Greeting: VER, NMETHODS, METHODS
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil || header[0] != 5 {
+		conn.Close()
+		return
+	}
+	if _, err := io.ReadFull(r, make([]byte, header[1])); err != nil {
+		conn.Close()
+		return
+	}
+	_, _ = conn.Write([]byte{5, 2}) // username/password auth
+
+	// Auth: VER, ULEN, UNAME, PLEN, PASSWD
+	username, password, err := readSOCKS5Credentials(r)
+	if err != nil || username != testUsername || password != testPassword {
+		_, _ = conn.Write([]byte{1, 1})
+		conn.Close()
+		return
+	}
+	_, _ = conn.Write([]byte{1, 0})
+
+	// Request: VER, CMD, RSV, ATYP, DST.ADDR, DST.PORT
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(r, request); err != nil || request[1] != 1 {
+		conn.Close()
+		return
+	}
+
+	var host string
+	switch request[3] {
+	case 1:
+		addr := make([]byte, net.IPv4len)
+		if _, err := io.ReadFull(r, addr); err != nil {
+			conn.Close()
+			return
+		}
+		host = net.IP(addr).String()
+	case 3:
+		addrLen, err := r.ReadByte()
+		if err != nil {
+			conn.Close()
+			return
+		}
+		addr := make([]byte, addrLen)
+		if _, err := io.ReadFull(r, addr); err != nil {
+			conn.Close()
+			return
+		}
+		host = string(addr)
+	default:
+		_, _ = conn.Write([]byte{5, 8, 0, 1, 0, 0, 0, 0, 0, 0})
+		conn.Close()
+		return
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(r, port); err != nil {
+		conn.Close()
+		return
+	}
+
+	dst, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))))
+	if err != nil {
+		_, _ = conn.Write([]byte{5, 5, 0, 1, 0, 0, 0, 0, 0, 0})
+		conn.Close()
+		return
+	}
+	_, _ = conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+
+	pipe(conn, dst)
+}
+
+func readSOCKS5Credentials(r *bufio.Reader) (string, string, error) {
+	if _, err := r.ReadByte(); err != nil {
+		return "", "", err
+	}
+
+	readString := func() (string, error) {
+		n, err := r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		b := make([]byte, n)
+		_, err = io.ReadFull(r, b)
+		return string(b), err
+	}
+
+	username, err := readString()
+	if err != nil {
+		return "", "", err
+	}
+	password, err := readString()
+	return username, password, err
+}
+
+func pipe(a, b net.Conn) {
+	go func() {
+		_, _ = io.Copy(a, b)
+		a.Close()
+	}()
+	_, _ = io.Copy(b, a)
+	b.Close()
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"proxy_manager/internal/domain"
+	"proxy_manager/internal/usecase"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresAPIKeyRepository struct {
+	connPool *pgxpool.Pool
+}
+
+func NewPostgresAPIKeyRepository(connPool *pgxpool.Pool) PostgresAPIKeyRepository {
+	return PostgresAPIKeyRepository{connPool: connPool}
+}
+
+func (p PostgresAPIKeyRepository) CreateAPIKey(ctx context.Context, key domain.APIKey) (domain.APIKey, error) {
+	q := "INSERT INTO api_key(name, role, prefix, key_hash) VALUES ($1, $2, $3, $4) RETURNING *;"
+	rows, _ := p.connPool.Query(ctx, q, key.Name, key.Role, key.Prefix, key.Hash)
+
+	return pgx.CollectOneRow(rows, pgx.RowToStructByName[domain.APIKey])
+}
+
+func (p PostgresAPIKeyRepository) GetActiveAPIKey(ctx context.Context, hash []byte) (domain.APIKey, error) {
+	q := "SELECT * FROM api_key WHERE key_hash = $1 AND revoked_at IS NULL;"
+	rows, _ := p.connPool.Query(ctx, q, hash)
+
+	key, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[domain.APIKey])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.APIKey{}, usecase.ErrAPIKeyNotFound
+		}
+		return domain.APIKey{}, err
+	}
+	return key, nil
+}
+
+func (p PostgresAPIKeyRepository) GetAPIKeyList(ctx context.Context) ([]domain.APIKey, error) {
+	q := "SELECT * FROM api_key ORDER BY api_key_id;"
+	rows, _ := p.connPool.Query(ctx, q)
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[domain.APIKey])
+}
+
+func (p PostgresAPIKeyRepository) RevokeAPIKey(ctx context.Context, keyID int64) error {
+	q := "UPDATE api_key SET revoked_at = COALESCE(revoked_at, now()) WHERE api_key_id = $1;"
+	tag, err := p.connPool.Exec(ctx, q, keyID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return usecase.ErrAPIKeyNotFound
+	}
+	return nil
+}
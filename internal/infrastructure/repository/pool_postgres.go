@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"proxy_manager/internal/domain"
+	"proxy_manager/internal/usecase"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// uniqueViolationCode is a postgres error code of unique constraint violation.
+const uniqueViolationCode = "23505"
+
+type PostgresPoolRepository struct {
+	connPool *pgxpool.Pool
+}
+
+func NewPostgresPoolRepository(connPool *pgxpool.Pool) PostgresPoolRepository {
+	return PostgresPoolRepository{connPool: connPool}
+}
+
+func (p PostgresPoolRepository) CreatePool(ctx context.Context, pool domain.Pool) (domain.Pool, error) {
+	q := "INSERT INTO pool(name, occupy_ttl, strategy, max_occupies) VALUES ($1, $2, $3, $4) RETURNING *, 0 AS proxies_count;"
+	rows, _ := p.connPool.Query(ctx, q, pool.Name, pool.OccupyTTL, pool.Strategy, pool.MaxOccupies)
+
+	createdPool, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[domain.Pool])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return domain.Pool{}, usecase.ErrAlreadyExists
+		}
+		return domain.Pool{}, err
+	}
+	return createdPool, nil
+}
+
+func (p PostgresPoolRepository) GetPool(ctx context.Context, name string) (domain.Pool, error) {
+	q := "SELECT pool.*, COUNT(proxy_pool.proxy_id) AS proxies_count FROM pool LEFT JOIN proxy_pool ON pool.pool_id = proxy_pool.pool_id WHERE pool.name = $1 GROUP BY pool.pool_id;"
+	rows, _ := p.connPool.Query(ctx, q, name)
+
+	pool, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[domain.Pool])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Pool{}, usecase.ErrPoolNotFound
+		}
+		return domain.Pool{}, err
+	}
+	return pool, nil
+}
+
+func (p PostgresPoolRepository) UpdatePool(ctx context.Context, pool domain.Pool) (domain.Pool, error) {
+	q := "UPDATE pool SET occupy_ttl = $2, strategy = $3, max_occupies = $4 WHERE name = $1 RETURNING *, (SELECT COUNT(*) FROM proxy_pool WHERE proxy_pool.pool_id = pool.pool_id) AS proxies_count;"
+	rows, _ := p.connPool.Query(ctx, q, pool.Name, pool.OccupyTTL, pool.Strategy, pool.MaxOccupies)
+
+	updatedPool, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[domain.Pool])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Pool{}, usecase.ErrPoolNotFound
+		}
+		return domain.Pool{}, err
+	}
+	return updatedPool, nil
+}
+
+func (p PostgresPoolRepository) DeletePool(ctx context.Context, name string) error {
+	q := "DELETE FROM pool WHERE name = $1;"
+	tag, err := p.connPool.Exec(ctx, q, name)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return usecase.ErrPoolNotFound
+	}
+	return nil
+}
+
+func (p PostgresPoolRepository) GetPoolList(ctx context.Context) ([]domain.Pool, error) {
+	q := "SELECT pool.*, COUNT(proxy_pool.proxy_id) AS proxies_count FROM pool LEFT JOIN proxy_pool ON pool.pool_id = proxy_pool.pool_id GROUP BY pool.pool_id ORDER BY pool.name;"
+	rows, _ := p.connPool.Query(ctx, q)
+
+	pools, err := pgx.CollectRows(rows, pgx.RowToStructByName[domain.Pool])
+	if err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+func (p PostgresPoolRepository) AddPoolProxies(ctx context.Context, name string, proxyIDs []int64) ([]int64, error) {
+	q1 := "SELECT pool_id FROM pool WHERE name = $1 FOR UPDATE;"
+	q2 := "SELECT id FROM unnest($1::bigint[]) AS id WHERE NOT EXISTS(SELECT 1 FROM proxy WHERE proxy.proxy_id = id) ORDER BY id;"
+	q3 := "INSERT INTO proxy_pool(pool_id, proxy_id) SELECT $1, unnest($2::bigint[]) ON CONFLICT DO NOTHING;"
+
+	tx, err := p.connPool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var poolID int64
+	if err := tx.QueryRow(ctx, q1, name).Scan(&poolID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecase.ErrPoolNotFound
+		}
+		return nil, err
+	}
+
+	rows, _ := tx.Query(ctx, q2, proxyIDs)
+	missingIDs, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, err
+	}
+	if len(missingIDs) > 0 {
+		return missingIDs, nil
+	}
+
+	if _, err := tx.Exec(ctx, q3, poolID, proxyIDs); err != nil {
+		return nil, err
+	}
+
+	// Added proxies may be occupied by waiters of pool, notification is delivered on commit
+	if _, err := tx.Exec(ctx, "SELECT pg_notify($1, '');", proxyReleasedChannel); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (p PostgresPoolRepository) RemovePoolProxies(ctx context.Context, name string, proxyIDs []int64) error {
+	q := "WITH p AS (SELECT pool_id FROM pool WHERE name = $1), deleted AS (DELETE FROM proxy_pool USING p WHERE proxy_pool.pool_id = p.pool_id AND proxy_pool.proxy_id = ANY($2)) SELECT COUNT(*) FROM p;"
+
+	var poolsCount int64
+	if err := p.connPool.QueryRow(ctx, q, name, proxyIDs).Scan(&poolsCount); err != nil {
+		return err
+	}
+	if poolsCount == 0 {
+		return usecase.ErrPoolNotFound
+	}
+	return nil
+}
@@ -3,9 +3,12 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"proxy_manager/internal/domain"
 	"proxy_manager/internal/usecase"
 	"proxy_manager/pkg/logger"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
@@ -13,35 +16,109 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// proxyReleasedChannel is notified every time some proxy may become available for occupy.
+const proxyReleasedChannel = "proxy_released"
+
 type PostgresProxyRepository struct {
 	connPool *pgxpool.Pool
 	l        logger.Interface
 }
 
-func NewPostgresProxyRepository(ctx context.Context, connPool *pgxpool.Pool, occupyExpireTime time.Duration, l logger.Interface) PostgresProxyRepository {
+func NewPostgresProxyRepository(ctx context.Context, connPool *pgxpool.Pool, l logger.Interface) PostgresProxyRepository {
 	ppr := PostgresProxyRepository{
 		connPool: connPool,
 		l:        l,
 	}
 
-	ppr.startExpiredOccupiesCleaner(ctx, occupyExpireTime)
+	ppr.startExpiredOccupiesCleaner(ctx)
 
 	return ppr
 }
 
 func (p PostgresProxyRepository) CreateProxy(ctx context.Context, proxy domain.Proxy) (domain.Proxy, error) {
-	q := "INSERT INTO proxy(protocol, username, password, host, port, expiration_date) VALUES ($1, $2, $3, $4, $5, $6) RETURNING *, (proxy.expiration_date > now() - INTERVAL '1 hour') AS enabled, 0 as occupies_count;"
-	rows, _ := p.connPool.Query(ctx, q, proxy.Protocol, proxy.Username, proxy.Password, proxy.Host, proxy.Port, proxy.ExpirationDate)
+	q := "INSERT INTO proxy(protocol, username, password, host, port, expiration_date, max_occupies, weight, tags) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING *, (proxy.expiration_date > now() - INTERVAL '1 hour') AS enabled, 0 as occupies_count;"
+
+	tx, err := p.connPool.Begin(ctx)
+	if err != nil {
+		return domain.Proxy{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := checkDuplicateProxy(ctx, tx, proxy); err != nil {
+		return domain.Proxy{}, err
+	}
 
+	rows, _ := tx.Query(ctx, q, proxy.Protocol, proxy.Username, proxy.Password, proxy.Host, proxy.Port, proxy.ExpirationDate, proxy.MaxOccupies, proxy.Weight, proxy.Tags)
 	createdProxy, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Proxy])
 	if err != nil {
 		return domain.Proxy{}, err
 	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Proxy{}, err
+	}
+
+	p.notifyProxyReleased(ctx)
 	return *createdProxy, nil
 }
 
+func (p PostgresProxyRepository) ImportProxies(ctx context.Context, proxies []domain.Proxy, skipDuplicates bool) ([]int64, error) {
+	// Statements of batch are executed in order, so duplicates within imported list are found too
+	q := `INSERT INTO proxy(protocol, username, password, host, port, expiration_date, max_occupies, weight, tags)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9
+		WHERE NOT EXISTS(SELECT 1 FROM proxy WHERE protocol = $1 AND username = $2 AND host = $4 AND port = $5)
+	RETURNING proxy_id;`
+
+	tx, err := p.connPool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Concurrent inserts of the same proxy must wait
+	if _, err := tx.Exec(ctx, "LOCK TABLE proxy IN SHARE ROW EXCLUSIVE MODE;"); err != nil {
+		return nil, err
+	}
+
+	batch := &pgx.Batch{}
+	for _, proxy := range proxies {
+		batch.Queue(q, proxy.Protocol, proxy.Username, proxy.Password, proxy.Host, proxy.Port, proxy.ExpirationDate,
+			proxy.MaxOccupies, proxy.Weight, proxy.Tags)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	ids := make([]int64, len(proxies))
+	duplicate := -1
+	for i := range proxies {
+		err := results.QueryRow().Scan(&ids[i])
+		if errors.Is(err, pgx.ErrNoRows) && !skipDuplicates && duplicate < 0 {
+			duplicate = i
+		} else if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			results.Close()
+			return nil, err
+		}
+	}
+	if err := results.Close(); err != nil {
+		return nil, err
+	}
+
+	if duplicate >= 0 {
+		if err := checkDuplicateProxy(ctx, tx, proxies[duplicate]); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("duplicate proxy disappeared during import")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	p.notifyProxyReleased(ctx)
+	return ids, nil
+}
+
 func (p PostgresProxyRepository) GetProxy(ctx context.Context, proxyID int64) (domain.Proxy, error) {
-	q := "SELECT proxy.*, (proxy.expiration_date > now() - INTERVAL '1 hour') AS enabled, COUNT(proxy_occupy.proxy_id) AS occupies_count FROM proxy LEFT JOIN proxy_occupy ON proxy.proxy_id = proxy_occupy.proxy_id WHERE proxy.proxy_id = $1 GROUP BY proxy.proxy_id;"
+	q := "SELECT proxy.*, (proxy.expiration_date > now() - INTERVAL '1 hour') AS enabled, COUNT(proxy_occupy.proxy_id) AS occupies_count FROM proxy LEFT JOIN proxy_occupy ON proxy.proxy_id = proxy_occupy.proxy_id AND proxy_occupy.expires_at > now() WHERE proxy.proxy_id = $1 GROUP BY proxy.proxy_id;"
 	rows, _ := p.connPool.Query(ctx, q, proxyID)
 
 	proxy, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Proxy])
@@ -54,57 +131,239 @@ func (p PostgresProxyRepository) GetProxy(ctx context.Context, proxyID int64) (d
 	return *proxy, nil
 }
 
-func (p PostgresProxyRepository) UpdateProxy(ctx context.Context, proxy domain.Proxy) (domain.Proxy, error) {
-	q1 := "UPDATE proxy SET protocol = $2, username = $3, password = $4,  host = $5, port = $6, expiration_date = $7 WHERE proxy_id = $1 RETURNING *, (proxy.expiration_date > now() - INTERVAL '1 hour') AS enabled, 0 as occupies_count;"
-	q2 := "DELETE FROM proxy_occupy WHERE proxy_id = $1;"
+func (p PostgresProxyRepository) UpdateProxy(ctx context.Context, proxy domain.Proxy, invalidateOccupies bool) (domain.Proxy, error) {
+	tx, err := p.connPool.Begin(ctx)
+	if err != nil {
+		return domain.Proxy{}, err
+	}
+	defer tx.Rollback(ctx)
 
-	rows, _ := p.connPool.Query(ctx, q1, proxy.ID, proxy.Protocol, proxy.Username, proxy.Password, proxy.Host, proxy.Port, proxy.ExpirationDate)
-	updatedProxy, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Proxy])
+	if err := lockOccupies(ctx, tx); err != nil {
+		return domain.Proxy{}, err
+	}
+
+	updatedProxy, err := updateProxyInTx(ctx, tx, proxy, invalidateOccupies)
+	if err != nil {
+		return domain.Proxy{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Proxy{}, err
+	}
+
+	p.notifyProxyReleased(ctx)
+	return updatedProxy, nil
+}
+
+func (p PostgresProxyRepository) ModifyProxy(ctx context.Context, proxyID int64, version int64, modify domain.ProxyModifier) (domain.Proxy, error) {
+	q := "SELECT proxy.*, (proxy.expiration_date > now() - INTERVAL '1 hour') AS enabled, (SELECT COUNT(*) FROM proxy_occupy WHERE proxy_occupy.proxy_id = proxy.proxy_id AND proxy_occupy.expires_at > now()) AS occupies_count FROM proxy WHERE proxy.proxy_id = $1 FOR UPDATE;"
+
+	tx, err := p.connPool.Begin(ctx)
+	if err != nil {
+		return domain.Proxy{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockOccupies(ctx, tx); err != nil {
+		return domain.Proxy{}, err
+	}
+
+	// Proxy is locked until commit, so it can't be changed between read and update
+	rows, _ := tx.Query(ctx, q, proxyID)
+	proxy, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[domain.Proxy])
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.Proxy{}, usecase.ErrNotFound
 		}
 		return domain.Proxy{}, err
 	}
+	if version != 0 && proxy.Version != version {
+		return domain.Proxy{}, usecase.ErrVersionMismatch
+	}
 
-	_, err = p.connPool.Query(ctx, q2, proxy.ID)
+	modifiedProxy, invalidateOccupies, err := modify(proxy)
 	if err != nil {
 		return domain.Proxy{}, err
 	}
+	modifiedProxy.ID, modifiedProxy.Version = proxy.ID, proxy.Version
 
+	updatedProxy, err := updateProxyInTx(ctx, tx, modifiedProxy, invalidateOccupies)
+	if err != nil {
+		return domain.Proxy{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Proxy{}, err
+	}
+
+	p.notifyProxyReleased(ctx)
+	return updatedProxy, nil
+}
+
+// updateProxyInTx updates proxy, if invalidateOccupies is set, all its occupies are deleted.
+// If proxy.Version is not 0, proxy is updated only if it has this version. proxy_occupy table must be locked by tx.
+func updateProxyInTx(ctx context.Context, tx pgx.Tx, proxy domain.Proxy, invalidateOccupies bool) (domain.Proxy, error) {
+	q1 := "DELETE FROM proxy_occupy WHERE proxy_id = $1;"
+	q2 := "UPDATE proxy SET protocol = $2, username = $3, password = $4,  host = $5, port = $6, expiration_date = $7, max_occupies = $8, weight = $9, tags = $10, version = version + 1 WHERE proxy_id = $1 AND ($11::bigint = 0 OR version = $11) RETURNING *, (proxy.expiration_date > now() - INTERVAL '1 hour') AS enabled, (SELECT COUNT(*) FROM proxy_occupy WHERE proxy_occupy.proxy_id = proxy.proxy_id AND proxy_occupy.expires_at > now()) AS occupies_count;"
+
+	if err := checkDuplicateProxy(ctx, tx, proxy); err != nil {
+		return domain.Proxy{}, err
+	}
+
+	if invalidateOccupies {
+		if _, err := tx.Exec(ctx, q1, proxy.ID); err != nil {
+			return domain.Proxy{}, err
+		}
+	}
+
+	rows, _ := tx.Query(ctx, q2, proxy.ID, proxy.Protocol, proxy.Username, proxy.Password, proxy.Host, proxy.Port, proxy.ExpirationDate, proxy.MaxOccupies, proxy.Weight, proxy.Tags, proxy.Version)
+	updatedProxy, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Proxy])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Proxy{}, missingProxyError(ctx, tx, proxy.ID)
+		}
+		return domain.Proxy{}, err
+	}
 	return *updatedProxy, nil
 }
 
-func (p PostgresProxyRepository) DeleteProxy(ctx context.Context, proxyID int64) error {
-	q := "DELETE FROM proxy WHERE proxy_id=$1;"
-	_, err := p.connPool.Query(ctx, q, proxyID)
+func (p PostgresProxyRepository) DeleteProxy(ctx context.Context, proxyID int64, version int64) error {
+	q := "DELETE FROM proxy WHERE proxy_id=$1 AND ($2::bigint = 0 OR version = $2);"
+
+	tx, err := p.connPool.Begin(ctx)
 	if err != nil {
 		return err
 	}
-	return nil
+	defer tx.Rollback(ctx)
+
+	// Occupies of proxy are deleted by cascade
+	if err := lockOccupies(ctx, tx); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, q, proxyID, version)
+	if err != nil {
+		return err
+	}
+
+	// Deletion of missing proxy is not an error, unless version is expected
+	if tag.RowsAffected() == 0 && version != 0 {
+		return missingProxyError(ctx, tx, proxyID)
+	}
+	return tx.Commit(ctx)
+}
+
+func (p PostgresProxyRepository) DedupeProxies(ctx context.Context) (domain.DedupeResult, error) {
+	// dup maps every duplicate to the proxy with the lowest ID among proxies with the same protocol, username, host and port
+	dup := `CREATE TEMPORARY TABLE dup ON COMMIT DROP AS
+		SELECT proxy_id, keep_id FROM (
+			SELECT proxy_id, min(proxy_id) OVER (PARTITION BY protocol, username, host, port) AS keep_id FROM proxy
+		) p WHERE proxy_id <> keep_id;`
+	// Kept proxy gets the latest expiration date and all tags of its duplicates
+	merge := `UPDATE proxy SET
+			expiration_date = GREATEST(proxy.expiration_date, m.expiration_date),
+			tags = ARRAY(SELECT DISTINCT unnest(proxy.tags || m.tags) ORDER BY 1),
+			version = proxy.version + 1
+		FROM (
+			SELECT dup.keep_id, max(d.expiration_date) AS expiration_date, array_agg(DISTINCT t.tag) FILTER (WHERE t.tag IS NOT NULL) AS tags
+			FROM dup JOIN proxy d ON d.proxy_id = dup.proxy_id LEFT JOIN LATERAL unnest(d.tags) AS t(tag) ON TRUE
+			GROUP BY dup.keep_id
+		) m WHERE proxy.proxy_id = m.keep_id;`
+	moveOccupies := "UPDATE proxy_occupy SET proxy_id = dup.keep_id FROM dup WHERE proxy_occupy.proxy_id = dup.proxy_id;"
+	moveOutcomes := "UPDATE proxy_outcome SET proxy_id = dup.keep_id FROM dup WHERE proxy_outcome.proxy_id = dup.proxy_id;"
+	movePools := "INSERT INTO proxy_pool(pool_id, proxy_id) SELECT proxy_pool.pool_id, dup.keep_id FROM proxy_pool JOIN dup ON proxy_pool.proxy_id = dup.proxy_id ON CONFLICT DO NOTHING;"
+	moveBans := `INSERT INTO proxy_ban(proxy_id, domain, reason, banned_until)
+		SELECT DISTINCT ON (dup.keep_id, proxy_ban.domain) dup.keep_id, proxy_ban.domain, proxy_ban.reason, proxy_ban.banned_until
+		FROM proxy_ban JOIN dup ON proxy_ban.proxy_id = dup.proxy_id
+		ORDER BY dup.keep_id, proxy_ban.domain, proxy_ban.banned_until DESC
+	ON CONFLICT (proxy_id, domain) DO UPDATE SET banned_until = GREATEST(proxy_ban.banned_until, excluded.banned_until), reason = excluded.reason
+		WHERE excluded.banned_until > proxy_ban.banned_until;`
+	deleteDuplicates := "DELETE FROM proxy USING dup WHERE proxy.proxy_id = dup.proxy_id;"
+
+	tx, err := p.connPool.Begin(ctx)
+	if err != nil {
+		return domain.DedupeResult{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Tables are locked in the same order as by occupy
+	if _, err := tx.Exec(ctx, "LOCK TABLE proxy_occupy, proxy IN EXCLUSIVE MODE;"); err != nil {
+		return domain.DedupeResult{}, err
+	}
+
+	var result domain.DedupeResult
+	for _, q := range []string{dup, merge, moveOccupies, moveOutcomes, movePools, moveBans, deleteDuplicates} {
+		tag, err := tx.Exec(ctx, q)
+		if err != nil {
+			return domain.DedupeResult{}, err
+		}
+
+		switch q {
+		case moveOccupies:
+			result.MovedOccupies = tag.RowsAffected()
+		case deleteDuplicates:
+			result.Merged = tag.RowsAffected()
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.DedupeResult{}, err
+	}
+
+	p.l.Info(fmt.Sprintf("Merged %d duplicate proxies, moved %d occupies", result.Merged, result.MovedOccupies))
+	return result, nil
 }
 
-func (p PostgresProxyRepository) GetProxyList(ctx context.Context, offset int64, limit int64) (domain.ProxyList, error) {
-	q := "WITH t AS (SELECT proxy.*, (proxy.expiration_date > now() - INTERVAL '1 hour') AS enabled, COUNT(proxy_occupy.proxy_id) AS occupies_count FROM proxy LEFT JOIN proxy_occupy ON proxy.proxy_id = proxy_occupy.proxy_id GROUP BY proxy.proxy_id) SELECT * FROM  (TABLE  t OFFSET $1 LIMIT  $2) sub RIGHT JOIN (SELECT count(*) FROM t) AS c(total) ON TRUE;"
-	rows, _ := p.connPool.Query(ctx, q, offset, limit)
+func (p PostgresProxyRepository) GetProxyList(ctx context.Context, filter domain.ProxyFilter, sort domain.ProxySort, page domain.ProxyPage) (domain.ProxyList, error) {
+	var args queryArgs
+	conds := listConditions(filter, &args)
+
+	pageConds := []string{"TRUE"}
+	offset := page.Offset
+	if page.Cursor != "" {
+		cursor, err := domain.DecodeProxyCursor(page.Cursor, sort)
+		if err != nil {
+			return domain.ProxyList{}, err
+		}
+		pageConds = append(pageConds, cursorCondition(cursor, &args))
+		offset = 0
+	}
+
+	// One extra proxy is selected to know whether there is a next page
+	order := listOrder(sort)
+	pageQuery := "SELECT * FROM t WHERE " + strings.Join(pageConds, " AND ") + " ORDER BY " + order + " OFFSET " + args.add(offset) + " LIMIT " + args.add(page.Limit+1)
+
+	q := "WITH t AS (SELECT proxy.*, (proxy.expiration_date > now() - INTERVAL '1 hour') AS enabled, COUNT(proxy_occupy.proxy_id) AS occupies_count FROM proxy LEFT JOIN proxy_occupy ON proxy.proxy_id = proxy_occupy.proxy_id AND proxy_occupy.expires_at > now() WHERE " + strings.Join(conds, " AND ") + " GROUP BY proxy.proxy_id) "
+	if page.WithTotal {
+		q += "SELECT * FROM (" + pageQuery + ") sub RIGHT JOIN (SELECT count(*) FROM t) AS c(total) ON TRUE ORDER BY " + order + ";"
+	} else {
+		q += pageQuery + ";"
+	}
+	rows, _ := p.connPool.Query(ctx, q, args...)
 	rowsAsMap, err := pgx.CollectRows(rows, pgx.RowToMap)
 	if err != nil {
 		return domain.ProxyList{}, err
 	}
 
-	proxyList := domain.ProxyList{
-		Total:  rowsAsMap[0]["total"].(int64),
-		Offset: offset,
+	proxyList := domain.ProxyList{Offset: offset}
+	if page.WithTotal {
+		total := rowsAsMap[0]["total"].(int64)
+		proxyList.Total = &total
+
+		// Если proxy_id в первой записи = null, значит из БД нам не вернулось ни одной прокси
+		// и вернулась только одна строка, в которой все поля, кроме "total", равны null
+		if rowsAsMap[0]["proxy_id"] == nil {
+			return proxyList, nil
+		}
 	}
 
-	// Если proxy_id в первой записи = null, значит из БД нам не вернулось ни одной прокси
-	// и вернулась только одна строка, в которой все поля, кроме "total", равны null
-	if rowsAsMap[0]["proxy_id"] == nil {
-		return proxyList, nil
+	hasNext := int64(len(rowsAsMap)) > page.Limit
+	if hasNext {
+		rowsAsMap = rowsAsMap[:page.Limit]
 	}
 
 	for _, row := range rowsAsMap {
-		proxyList.Proxies = append(proxyList.Proxies, domain.Proxy{
+		proxy := domain.Proxy{
 			ID:             row["proxy_id"].(int64),
 			Protocol:       row["protocol"].(string),
 			Host:           row["host"].(string),
@@ -114,15 +373,35 @@ func (p PostgresProxyRepository) GetProxyList(ctx context.Context, offset int64,
 			ExpirationDate: row["expiration_date"].(time.Time),
 			Enabled:        row["enabled"].(bool),
 			OccupiesCount:  row["occupies_count"].(int64),
-		})
+			MaxOccupies:    row["max_occupies"].(int64),
+			Weight:         row["weight"].(int64),
+			Healthy:        row["healthy"].(bool),
+			CheckFailures:  row["check_failures"].(int64),
+			Latency:        row["latency"].(int64),
+			CheckError:     row["check_error"].(string),
+			Score:          row["score"].(float64),
+			Tags:           tagsFromRow(row["tags"]),
+			Version:        row["version"].(int64),
+		}
+		if checkedAt, ok := row["checked_at"].(time.Time); ok {
+			proxy.CheckedAt = &checkedAt
+		}
+		if lastOccupiedAt, ok := row["last_occupied_at"].(time.Time); ok {
+			proxy.LastOccupiedAt = &lastOccupiedAt
+		}
+		if cooldownUntil, ok := row["cooldown_until"].(time.Time); ok {
+			proxy.CooldownUntil = &cooldownUntil
+		}
+		proxyList.Proxies = append(proxyList.Proxies, proxy)
+	}
+
+	if hasNext && len(proxyList.Proxies) > 0 {
+		proxyList.NextCursor = domain.NewProxyCursor(sort, proxyList.Proxies[len(proxyList.Proxies)-1]).Encode()
 	}
 	return proxyList, nil
 }
 
-func (p PostgresProxyRepository) OccupyMostAvailableProxy(ctx context.Context) (domain.ProxyOccupy, error) {
-	selectQuery := "SELECT proxy.*, (proxy.expiration_date > now() - INTERVAL '1 hour') AS enabled, COUNT(proxy_occupy.proxy_id) AS occupies_count FROM proxy LEFT JOIN proxy_occupy ON proxy.proxy_id = proxy_occupy.proxy_id WHERE expiration_date > now() - INTERVAL '1 hour' GROUP BY proxy.proxy_id ORDER BY occupies_count ASC LIMIT 1;"
-	occupyQuery := "INSERT INTO proxy_occupy(proxy_id, create_timestamp) VALUES($1, EXTRACT(EPOCH FROM CURRENT_TIMESTAMP)) RETURNING *;"
-
+func (p PostgresProxyRepository) OccupyMostAvailableProxy(ctx context.Context, opts domain.OccupyOptions, picker domain.ProxyPicker) (domain.ProxyOccupy, error) {
 	tx, err := p.connPool.Begin(ctx)
 	if err != nil {
 		return domain.ProxyOccupy{}, err
@@ -134,17 +413,120 @@ func (p PostgresProxyRepository) OccupyMostAvailableProxy(ctx context.Context) (
 		return domain.ProxyOccupy{}, err
 	}
 
-	rows, _ := tx.Query(ctx, selectQuery)
+	proxyOccupy, err := occupyInTx(ctx, tx, opts, picker, nil)
+	if err != nil {
+		return domain.ProxyOccupy{}, err
+	}
 
-	proxy, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Proxy])
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ProxyOccupy{}, err
+	}
+	return proxyOccupy, nil
+}
+
+func (p PostgresProxyRepository) OccupyProxies(ctx context.Context, opts domain.BatchOccupyOptions, picker domain.ProxyPicker) ([]domain.ProxyOccupy, error) {
+	tx, err := p.connPool.Begin(ctx)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return domain.ProxyOccupy{}, usecase.ErrNotFound
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, "LOCK TABLE proxy_occupy IN EXCLUSIVE MODE;")
+	if err != nil {
+		return nil, err
+	}
+
+	occupyOpts := opts.OccupyOptions
+	occupyOpts.ExcludeIDs = append([]int64{}, opts.ExcludeIDs...)
+	var excludeHosts []string
+
+	proxyOccupies := make([]domain.ProxyOccupy, 0, opts.Count)
+	for int64(len(proxyOccupies)) < opts.Count {
+		proxyOccupy, err := occupyInTx(ctx, tx, occupyOpts, picker, excludeHosts)
+		if err != nil {
+			noProxy := errors.Is(err, usecase.ErrNotFound) || errors.Is(err, usecase.ErrProxiesSaturated)
+			if noProxy && opts.BestEffort && len(proxyOccupies) > 0 {
+				break
+			}
+			return nil, err
+		}
+
+		proxyOccupies = append(proxyOccupies, proxyOccupy)
+		occupyOpts.ExcludeIDs = append(occupyOpts.ExcludeIDs, proxyOccupy.Proxy.ID)
+		if opts.DistinctHosts {
+			excludeHosts = append(excludeHosts, proxyOccupy.Proxy.Host)
 		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return proxyOccupies, nil
+}
+
+// occupyInTx occupies proxy chosen by picker among available proxies matching opts, whose host is not in excludeHosts.
+// proxy_occupy table must be locked by tx.
+func occupyInTx(ctx context.Context, tx pgx.Tx, opts domain.OccupyOptions, picker domain.ProxyPicker, excludeHosts []string) (domain.ProxyOccupy, error) {
+	where, args := occupyConditions(opts)
+	if len(excludeHosts) > 0 {
+		where += " AND proxy.host <> ALL(" + args.add(excludeHosts) + ")"
+	}
+
+	whereArgs := args
+
+	// Ordered picker's choice is made by query, other pickers choose among all available proxies
+	order, limit := "proxy.proxy_id", ""
+	var pickKeys []string
+	if ordered, ok := picker.(domain.OrderedProxyPicker); ok {
+		pickKeys = ordered.PickOrder()
+		pickOrder, err := pickOrderClause(pickKeys, opts.PoolID, &args)
+		if err != nil {
+			return domain.ProxyOccupy{}, err
+		}
+		order, limit = pickOrder+", proxy.proxy_id", " LIMIT 1"
+	}
+
+	selectQuery := "SELECT proxy.*, (proxy.expiration_date > now() - INTERVAL '1 hour') AS enabled, COUNT(proxy_occupy.proxy_id) AS occupies_count FROM proxy LEFT JOIN proxy_occupy ON proxy.proxy_id = proxy_occupy.proxy_id AND proxy_occupy.expires_at > now() WHERE " + where + " GROUP BY proxy.proxy_id HAVING " + occupyAvailability(opts) + " ORDER BY " + order + limit + ";"
+	existsQuery := "SELECT EXISTS(SELECT 1 FROM proxy WHERE " + where + ");"
+	occupyQuery := "INSERT INTO proxy_occupy(proxy_id, create_timestamp, ttl, expires_at, exclusive) VALUES($1, EXTRACT(EPOCH FROM CURRENT_TIMESTAMP), $2, now() + make_interval(secs => $2), $3) RETURNING *;"
+	lastOccupiedQuery := "UPDATE proxy SET last_occupied_at = now() WHERE proxy_id = $1 RETURNING last_occupied_at;"
+	roundRobinQuery := "INSERT INTO round_robin_cursor(pool_id, last_proxy_id) VALUES($1, $2) ON CONFLICT (pool_id) DO UPDATE SET last_proxy_id = excluded.last_proxy_id;"
+
+	rows, _ := tx.Query(ctx, selectQuery, args...)
+	candidates, err := pgx.CollectRows(rows, pgx.RowToStructByName[domain.Proxy])
+	if err != nil {
 		return domain.ProxyOccupy{}, err
 	}
 
-	rows, _ = tx.Query(ctx, occupyQuery, proxy.ID)
+	if len(candidates) == 0 {
+		// Tell "there is no such proxies" from "all such proxies are occupied to their limit"
+		var exists bool
+		if err := tx.QueryRow(ctx, existsQuery, whereArgs...).Scan(&exists); err != nil {
+			return domain.ProxyOccupy{}, err
+		}
+		if exists {
+			return domain.ProxyOccupy{}, usecase.ErrProxiesSaturated
+		}
+		return domain.ProxyOccupy{}, usecase.ErrNotFound
+	}
+
+	proxy := picker.Pick(candidates)
+
+	var lastOccupiedAt time.Time
+	if err := tx.QueryRow(ctx, lastOccupiedQuery, proxy.ID).Scan(&lastOccupiedAt); err != nil {
+		return domain.ProxyOccupy{}, err
+	}
+	proxy.LastOccupiedAt = &lastOccupiedAt
+
+	for _, key := range pickKeys {
+		if key == domain.PickByRoundRobin {
+			if _, err := tx.Exec(ctx, roundRobinQuery, opts.PoolID, proxy.ID); err != nil {
+				return domain.ProxyOccupy{}, err
+			}
+		}
+	}
+
+	rows, _ = tx.Query(ctx, occupyQuery, proxy.ID, opts.TTL.Seconds(), opts.Exclusive)
 	occupyRowMap, err := pgx.CollectOneRow(rows, pgx.RowToMap)
 	if err != nil {
 		return domain.ProxyOccupy{}, err
@@ -160,32 +542,165 @@ func (p PostgresProxyRepository) OccupyMostAvailableProxy(ctx context.Context) (
 		return domain.ProxyOccupy{}, err
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return domain.ProxyOccupy{}, err
+	expiresAt, ok := occupyRowMap["expires_at"].(time.Time)
+	if !ok {
+		return domain.ProxyOccupy{}, errors.New("can't convert occupy.expires_at to time.Time")
 	}
 
 	proxy.OccupiesCount += 1
 	return domain.ProxyOccupy{
-		Proxy: *proxy,
-		Key:   key.String(),
+		Proxy:     proxy,
+		Key:       key.String(),
+		ExpiresAt: expiresAt,
+		Exclusive: opts.Exclusive,
+	}, nil
+}
+
+func (p PostgresProxyRepository) ReleaseProxy(ctx context.Context, key string, outcome *domain.ProxyOutcome, scoring domain.ProxyScoring) error {
+	if outcome == nil {
+		q := "WITH released AS (DELETE FROM proxy_occupy WHERE key=$1 RETURNING proxy_id) SELECT pg_notify('" + proxyReleasedChannel + "', '') FROM released;"
+		_, err := p.connPool.Exec(ctx, q, key)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	// Score is an exponentially weighted moving average of outcomes, where success is 1 and any failure is 0.
+	// Data-modifying statements in WITH are executed even if they are not referenced by the primary query.
+	q := `WITH released AS (DELETE FROM proxy_occupy WHERE key = $1 RETURNING proxy_id),
+	stored AS (
+		INSERT INTO proxy_outcome(proxy_id, status, reason, bytes, latency)
+		SELECT proxy_id, $2, $3, $4, $5 FROM released
+	),
+	scored AS (
+		UPDATE proxy SET
+			score = proxy.score * (1 - $7::float8) + $7::float8 * $6::float8,
+			cooldown_until = CASE
+				WHEN proxy.score * (1 - $7::float8) + $7::float8 * $6::float8 < $8::float8 THEN now() + make_interval(secs => $9)
+				ELSE proxy.cooldown_until
+			END
+		FROM released WHERE proxy.proxy_id = released.proxy_id
+	)
+	SELECT pg_notify('` + proxyReleasedChannel + `', '') FROM released;`
+
+	var success float64
+	if outcome.Success() {
+		success = 1
+	}
+
+	_, err := p.connPool.Exec(ctx, q, key, outcome.Status, outcome.Reason, outcome.Bytes, outcome.Latency.Milliseconds(),
+		success, scoring.Decay, scoring.MinScore, scoring.Cooldown.Seconds())
+	return err
+}
+
+func (p PostgresProxyRepository) BanProxy(ctx context.Context, key string, targetDomain string, reason string, cooldown time.Duration) (domain.ProxyBan, error) {
+	q := `INSERT INTO proxy_ban(proxy_id, domain, reason, banned_until)
+		SELECT proxy_id, $2, $3, now() + make_interval(secs => $4) FROM proxy_occupy WHERE key = $1 AND expires_at > now()
+	ON CONFLICT (proxy_id, domain) DO UPDATE SET
+		reason = EXCLUDED.reason,
+		banned_until = GREATEST(proxy_ban.banned_until, EXCLUDED.banned_until)
+	RETURNING proxy_id, domain, reason, banned_until;`
+
+	var ban domain.ProxyBan
+	err := p.connPool.QueryRow(ctx, q, key, targetDomain, reason, cooldown.Seconds()).
+		Scan(&ban.ProxyID, &ban.Domain, &ban.Reason, &ban.BannedUntil)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ProxyBan{}, usecase.ErrNotFound
+		}
+		return domain.ProxyBan{}, err
+	}
+	return ban, nil
+}
+
+func (p PostgresProxyRepository) RenewProxyOccupy(ctx context.Context, key string) (domain.ProxyOccupy, error) {
+	q := "UPDATE proxy_occupy SET expires_at = now() + make_interval(secs => ttl) WHERE key = $1 AND expires_at > now() RETURNING proxy_id, expires_at, exclusive;"
+
+	var proxyID int64
+	var expiresAt time.Time
+	var exclusive bool
+	if err := p.connPool.QueryRow(ctx, q, key).Scan(&proxyID, &expiresAt, &exclusive); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ProxyOccupy{}, usecase.ErrNotFound
+		}
+		return domain.ProxyOccupy{}, err
+	}
+
+	proxy, err := p.GetProxy(ctx, proxyID)
+	if err != nil {
+		return domain.ProxyOccupy{}, err
+	}
+
+	return domain.ProxyOccupy{
+		Proxy:     proxy,
+		Key:       key,
+		ExpiresAt: expiresAt,
+		Exclusive: exclusive,
 	}, nil
 }
 
-func (p PostgresProxyRepository) ReleaseProxy(ctx context.Context, key string) error {
-	q := "DELETE FROM proxy_occupy WHERE key=$1;"
-	_, err := p.connPool.Query(ctx, q, key)
+func (p PostgresProxyRepository) GetProxiesToCheck(ctx context.Context) ([]domain.Proxy, error) {
+	q := "SELECT proxy.*, (proxy.expiration_date > now() - INTERVAL '1 hour') AS enabled, 0 AS occupies_count FROM proxy WHERE proxy.expiration_date > now() - INTERVAL '1 hour' ORDER BY proxy.proxy_id;"
+	rows, _ := p.connPool.Query(ctx, q)
+
+	proxies, err := pgx.CollectRows(rows, pgx.RowToStructByName[domain.Proxy])
+	if err != nil {
+		return nil, err
+	}
+	return proxies, nil
+}
+
+func (p PostgresProxyRepository) SaveProxyCheck(ctx context.Context, check domain.ProxyCheck, maxFailures int64) error {
+	// Right side of SET sees values before update, so check_failures + 1 is a new failures count
+	q := `UPDATE proxy SET
+		checked_at = $2,
+		check_error = $3,
+		latency = CASE WHEN $4 THEN $5 ELSE latency END,
+		check_failures = CASE WHEN $4 THEN 0 ELSE check_failures + 1 END,
+		healthy = $4 OR check_failures + 1 < $6
+	WHERE proxy_id = $1;`
+
+	_, err := p.connPool.Exec(ctx, q, check.ProxyID, check.CheckedAt.UTC(), check.Error, check.Success,
+		check.Latency.Milliseconds(), maxFailures)
+	return err
+}
+
+func (p PostgresProxyRepository) ListenProxyReleases(ctx context.Context, notify func()) error {
+	conn, err := p.connPool.Acquire(ctx)
 	if err != nil {
 		return err
 	}
-	return nil
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+proxyReleasedChannel+";"); err != nil {
+		return err
+	}
+
+	for {
+		if _, err := conn.Conn().WaitForNotification(ctx); err != nil {
+			return err
+		}
+		notify()
+	}
 }
 
-func (p PostgresProxyRepository) startExpiredOccupiesCleaner(ctx context.Context, expireTime time.Duration) {
-	go p.expiredOccupiesCleaner(ctx, expireTime)
+// notifyProxyReleased wakes up listeners of proxyReleasedChannel, error is only logged,
+// because listeners are woken up periodically anyway.
+func (p PostgresProxyRepository) notifyProxyReleased(ctx context.Context) {
+	if _, err := p.connPool.Exec(ctx, "SELECT pg_notify($1, '');", proxyReleasedChannel); err != nil {
+		p.l.Error("PostgresProxyRepository - notifyProxyReleased - %s", err)
+	}
 }
 
-func (p PostgresProxyRepository) expiredOccupiesCleaner(ctx context.Context, expireTime time.Duration) {
-	q := "DELETE FROM proxy_occupy WHERE EXTRACT(EPOCH FROM CURRENT_TIMESTAMP) - create_timestamp > $1;"
+func (p PostgresProxyRepository) startExpiredOccupiesCleaner(ctx context.Context) {
+	go p.expiredOccupiesCleaner(ctx)
+}
+
+func (p PostgresProxyRepository) expiredOccupiesCleaner(ctx context.Context) {
+	// Identical notifications within one transaction are delivered once
+	q := "WITH expired AS (DELETE FROM proxy_occupy WHERE expires_at <= now() RETURNING proxy_id) SELECT pg_notify('" + proxyReleasedChannel + "', '') FROM expired;"
+	q2 := "DELETE FROM proxy_ban WHERE banned_until <= now();"
 
 	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
@@ -198,10 +713,255 @@ func (p PostgresProxyRepository) expiredOccupiesCleaner(ctx context.Context, exp
 		case <-ctx.Done():
 			return
 		default:
-			_, err := p.connPool.Query(ctx, q, expireTime.Seconds())
+			_, err := p.connPool.Exec(ctx, q)
 			if err != nil {
 				p.l.Error("PostgresProxyRepository - expiredOccupiesCleaner - %s", err)
 			}
+			_, err = p.connPool.Exec(ctx, q2)
+			if err != nil {
+				p.l.Error("PostgresProxyRepository - expiredOccupiesCleaner - %s", err)
+			}
+		}
+	}
+}
+
+// occupyAvailability returns aggregate expression, that is true for proxy, which can be occupied with opts right now.
+func occupyAvailability(opts domain.OccupyOptions) string {
+	maxOccupies := "proxy.max_occupies"
+	if opts.DefaultMaxOccupies > 0 {
+		// Integer is safe to inline, so args of WHERE clause are left untouched
+		maxOccupies = "(CASE WHEN proxy.max_occupies > 0 THEN proxy.max_occupies ELSE " + strconv.FormatInt(opts.DefaultMaxOccupies, 10) + " END)"
+	}
+
+	available := "(" + maxOccupies + " = 0 OR COUNT(proxy_occupy.proxy_id) < " + maxOccupies + ") AND NOT COALESCE(bool_or(proxy_occupy.exclusive), FALSE)"
+	if opts.Exclusive {
+		available += " AND COUNT(proxy_occupy.proxy_id) = 0"
+	}
+	return "(" + available + ")"
+}
+
+// pickOrderColumns maps keys of candidates order to ORDER BY expressions of occupy query.
+var pickOrderColumns = map[string]string{
+	domain.PickByOccupiesCount:  "COUNT(proxy_occupy.proxy_id)",
+	domain.PickByLastOccupiedAt: "proxy.last_occupied_at ASC NULLS FIRST",
+	domain.PickByLatency:        "proxy.latency = 0, proxy.latency",
+	domain.PickByScore:          "proxy.score DESC",
+	// The least of exponentially distributed keys with rate of weight is picked with probability proportional to weight
+	domain.PickByWeight: "proxy.weight <= 0, CASE WHEN proxy.weight > 0 THEN -ln(1 - random()) / proxy.weight ELSE random() END",
+	domain.PickByRandom: "random()",
+}
+
+// pickOrderClause returns ORDER BY clause of occupy query for keys of candidates order,
+// round-robin turn is read from cursor of pool with poolID, 0 for occupies not from pool.
+func pickOrderClause(keys []string, poolID int64, args *queryArgs) (string, error) {
+	columns := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key == domain.PickByRoundRobin {
+			// Proxies after the last picked one go first, then the turn starts over
+			columns = append(columns, "proxy.proxy_id <= COALESCE((SELECT last_proxy_id FROM round_robin_cursor WHERE pool_id = "+
+				args.add(poolID)+"), 0)")
+			continue
+		}
+
+		column, ok := pickOrderColumns[key]
+		if !ok {
+			return "", fmt.Errorf("unknown pick order key %q", key)
+		}
+		columns = append(columns, column)
+	}
+	return strings.Join(columns, ", "), nil
+}
+
+// occupyConditions builds WHERE clause, that selects enabled, healthy and not cooling down proxies matching opts,
+// which are not banned for target domain.
+func occupyConditions(opts domain.OccupyOptions) (string, queryArgs) {
+	var args queryArgs
+	conds := []string{
+		"proxy.expiration_date > now() - INTERVAL '1 hour'",
+		"proxy.healthy",
+		"(proxy.cooldown_until IS NULL OR proxy.cooldown_until <= now())",
+	}
+
+	if opts.MinLifetime > 0 {
+		conds = append(conds, "proxy.expiration_date > now() + make_interval(secs => "+args.add(opts.MinLifetime.Seconds())+")")
+	}
+
+	if opts.Protocol != "" {
+		conds = append(conds, "proxy.protocol = "+args.add(opts.Protocol))
+	}
+	if opts.HostPattern != "" {
+		conds = append(conds, "proxy.host ILIKE "+args.add(globToLike(opts.HostPattern)))
+	}
+	if len(opts.ExcludeIDs) > 0 {
+		conds = append(conds, "proxy.proxy_id <> ALL("+args.add(opts.ExcludeIDs)+")")
+	}
+	conds = append(conds, tagConditions(opts.TagFilter, &args)...)
+	if opts.PoolID != 0 {
+		conds = append(conds, "EXISTS(SELECT 1 FROM proxy_pool WHERE proxy_pool.proxy_id = proxy.proxy_id AND proxy_pool.pool_id = "+args.add(opts.PoolID)+")")
+	}
+	if opts.TargetDomain != "" {
+		conds = append(conds, "NOT EXISTS(SELECT 1 FROM proxy_ban WHERE proxy_ban.proxy_id = proxy.proxy_id AND proxy_ban.domain = "+
+			args.add(opts.TargetDomain)+" AND proxy_ban.banned_until > now())")
+	}
+
+	return strings.Join(conds, " AND "), args
+}
+
+// missingProxyError returns error for proxy, that wasn't changed by query with version condition:
+// usecase.ErrVersionMismatch if proxy exists, usecase.ErrNotFound otherwise.
+func missingProxyError(ctx context.Context, q rowQuerier, proxyID int64) error {
+	var exists bool
+	if err := q.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM proxy WHERE proxy_id = $1);", proxyID).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return usecase.ErrVersionMismatch
+	}
+	return usecase.ErrNotFound
+}
+
+// lockOccupies locks proxy_occupy table until the end of tx against occupies. Transactions, that change
+// both proxy and proxy_occupy tables, must lock proxy_occupy first, as occupy does, otherwise they may deadlock.
+func lockOccupies(ctx context.Context, tx pgx.Tx) error {
+	_, err := tx.Exec(ctx, "LOCK TABLE proxy_occupy IN ROW EXCLUSIVE MODE;")
+	return err
+}
+
+// checkDuplicateProxy locks proxy table until the end of tx and returns *usecase.DuplicateProxyError
+// if there is another proxy with the same protocol, username, host and port.
+func checkDuplicateProxy(ctx context.Context, tx pgx.Tx, proxy domain.Proxy) error {
+	// Concurrent inserts and updates of the same proxy must wait
+	if _, err := tx.Exec(ctx, "LOCK TABLE proxy IN SHARE ROW EXCLUSIVE MODE;"); err != nil {
+		return err
+	}
+
+	q := "SELECT proxy_id FROM proxy WHERE protocol = $1 AND username = $2 AND host = $3 AND port = $4 AND proxy_id <> $5 ORDER BY proxy_id LIMIT 1;"
+	var existingID int64
+	err := tx.QueryRow(ctx, q, proxy.Protocol, proxy.Username, proxy.Host, proxy.Port, proxy.ID).Scan(&existingID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return &usecase.DuplicateProxyError{ProxyID: existingID}
+}
+
+// listConditions returns conditions of proxy list query, that match filter.
+func listConditions(filter domain.ProxyFilter, args *queryArgs) []string {
+	conds := append([]string{"TRUE"}, tagConditions(filter.TagFilter, args)...)
+	if filter.Pool != "" {
+		conds = append(conds, "EXISTS(SELECT 1 FROM proxy_pool JOIN pool ON pool.pool_id = proxy_pool.pool_id WHERE proxy_pool.proxy_id = proxy.proxy_id AND pool.name = "+args.add(filter.Pool)+")")
+	}
+	if filter.Enabled != nil {
+		conds = append(conds, "(proxy.expiration_date > now() - INTERVAL '1 hour') = "+args.add(*filter.Enabled))
+	}
+	if filter.Protocol != "" {
+		conds = append(conds, "proxy.protocol = "+args.add(filter.Protocol))
+	}
+	if filter.HostContains != "" {
+		conds = append(conds, "proxy.host ILIKE "+args.add("%"+escapeLike(filter.HostContains)+"%"))
+	}
+	if filter.Port != 0 {
+		conds = append(conds, "proxy.port = "+args.add(filter.Port))
+	}
+	if filter.ExpiresAfter != nil {
+		conds = append(conds, "proxy.expiration_date > "+args.add(*filter.ExpiresAfter))
+	}
+	if filter.ExpiresBefore != nil {
+		conds = append(conds, "proxy.expiration_date < "+args.add(*filter.ExpiresBefore))
+	}
+	if filter.Occupied != nil {
+		conds = append(conds, "EXISTS(SELECT 1 FROM proxy_occupy o WHERE o.proxy_id = proxy.proxy_id AND o.expires_at > now()) = "+args.add(*filter.Occupied))
+	}
+	return conds
+}
+
+// sortColumns maps sort fields to columns of proxy list query, only these columns can be used in ORDER BY.
+var sortColumns = map[string]string{
+	domain.SortByID:             "proxy_id",
+	domain.SortByExpirationDate: "expiration_date",
+	domain.SortByOccupiesCount:  "occupies_count",
+	domain.SortByLatency:        "latency",
+}
+
+// listOrder returns ORDER BY clause of proxy list query, proxy_id is used as a tie-breaker.
+func listOrder(sort domain.ProxySort) string {
+	direction := " ASC"
+	if sort.Desc {
+		direction = " DESC"
+	}
+
+	column, ok := sortColumns[sort.Field]
+	if !ok || column == "proxy_id" {
+		return "proxy_id" + direction
+	}
+	return column + direction + ", proxy_id" + direction
+}
+
+// cursorCondition returns condition of proxy list query, that selects proxies after cursor in its sort order.
+func cursorCondition(cursor domain.ProxyCursor, args *queryArgs) string {
+	op := " > "
+	if cursor.Desc {
+		op = " < "
+	}
+
+	switch cursor.Field {
+	case domain.SortByExpirationDate:
+		return "(expiration_date, proxy_id)" + op + "(" + args.add(*cursor.ExpirationDate) + ", " + args.add(cursor.ID) + ")"
+	case domain.SortByOccupiesCount:
+		return "(occupies_count, proxy_id)" + op + "(" + args.add(cursor.OccupiesCount) + ", " + args.add(cursor.ID) + ")"
+	case domain.SortByLatency:
+		return "(latency, proxy_id)" + op + "(" + args.add(cursor.Latency) + ", " + args.add(cursor.ID) + ")"
+	default:
+		return "proxy_id" + op + args.add(cursor.ID)
+	}
+}
+
+// tagConditions returns WHERE conditions, that select proxies matching filter.
+func tagConditions(filter domain.TagFilter, args *queryArgs) []string {
+	var conds []string
+	if len(filter.AnyTags) > 0 {
+		conds = append(conds, "proxy.tags && "+args.add(filter.AnyTags)+"::text[]")
+	}
+	if len(filter.AllTags) > 0 {
+		conds = append(conds, "proxy.tags @> "+args.add(filter.AllTags)+"::text[]")
+	}
+	return conds
+}
+
+// tagsFromRow converts tags column value, scanned by pgx.RowToMap, to []string.
+func tagsFromRow(v any) []string {
+	values, _ := v.([]any)
+	tags := make([]string, 0, len(values))
+	for _, value := range values {
+		if tag, ok := value.(string); ok {
+			tags = append(tags, tag)
 		}
 	}
+	return tags
+}
+
+// globToLike converts glob pattern with "*" and "?" wildcards to LIKE pattern.
+func globToLike(pattern string) string {
+	return strings.NewReplacer("*", "%", "?", "_").Replace(escapeLike(pattern))
+}
+
+// escapeLike escapes LIKE wildcards in s, so it matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// rowQuerier is implemented by both pool and transaction.
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// queryArgs collects positional arguments of dynamically built query.
+type queryArgs []any
+
+// add appends v to args and returns its placeholder.
+func (a *queryArgs) add(v any) string {
+	*a = append(*a, v)
+	return fmt.Sprintf("$%d", len(*a))
 }
@@ -2,8 +2,14 @@ package repository_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"proxy_manager/internal/domain"
 	"proxy_manager/internal/infrastructure/repository"
+	"proxy_manager/internal/usecase"
 	"proxy_manager/pkg/logger"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,10 +26,404 @@ func TestPostgresProxyRepository_OccupyMostAvailableProxy(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	repo := repository.NewPostgresProxyRepository(context.Background(), pgxPool, time.Minute*3, logger.NewTestLogger(t))
-	proxyOccupy, err := repo.OccupyMostAvailableProxy(ctx)
+	repo := repository.NewPostgresProxyRepository(context.Background(), pgxPool, logger.NewTestLogger(t))
+	proxyOccupy, err := repo.OccupyMostAvailableProxy(ctx, domain.OccupyOptions{TTL: time.Minute * 3}, firstPicker{})
 	if err != nil {
 		t.Fatal(err)
 	}
 	t.Log(proxyOccupy)
 }
+
+// firstPicker picks the first candidate.
+type firstPicker struct{}
+
+func (firstPicker) Pick(candidates []domain.Proxy) domain.Proxy {
+	return candidates[0]
+}
+
+func newTestProxyRepository(t *testing.T) (repository.PostgresProxyRepository, *pgxpool.Pool) {
+	t.Helper()
+
+	pgxPool, err := pgxpool.New(context.Background(), testPostgresURL+"?pool_max_conns=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pgxPool.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return repository.NewPostgresProxyRepository(ctx, pgxPool, logger.NewTestLogger(t)), pgxPool
+}
+
+// newTestTag returns tag, that is unique for test run, so tests can select only proxies they created.
+func newTestTag() string {
+	return "test-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+// createTestProxies creates count proxies with given tag, which are deleted after test.
+func createTestProxies(t *testing.T, repo repository.PostgresProxyRepository, tag string, count int, modify func(i int, proxy *domain.Proxy)) []domain.Proxy {
+	t.Helper()
+	ctx := context.Background()
+
+	proxies := make([]domain.Proxy, 0, count)
+	for i := 0; i < count; i++ {
+		proxy := domain.Proxy{
+			Protocol:       "http",
+			Host:           fmt.Sprintf("%s-%d.example.com", tag, i),
+			Port:           8080,
+			ExpirationDate: time.Now().Add(time.Hour * 24),
+			Weight:         domain.DefaultProxyWeight,
+			Tags:           []string{tag},
+		}
+		if modify != nil {
+			modify(i, &proxy)
+		}
+
+		createdProxy, err := repo.CreateProxy(ctx, proxy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			if err := repo.DeleteProxy(context.Background(), createdProxy.ID, 0); err != nil && !errors.Is(err, usecase.ErrNotFound) {
+				t.Error(err)
+			}
+		})
+		proxies = append(proxies, createdProxy)
+	}
+	return proxies
+}
+
+func TestPostgresProxyRepository_RenewProxyOccupy(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestProxyRepository(t)
+	tag := newTestTag()
+	createTestProxies(t, repo, tag, 1, nil)
+
+	opts := domain.OccupyOptions{TTL: time.Minute, TagFilter: domain.TagFilter{AllTags: []string{tag}}}
+	proxyOccupy, err := repo.OccupyMostAvailableProxy(ctx, opts, firstPicker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(time.Millisecond * 100)
+	renewedOccupy, err := repo.RenewProxyOccupy(ctx, proxyOccupy.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !renewedOccupy.ExpiresAt.After(proxyOccupy.ExpiresAt) {
+		t.Fatalf("expected occupy expiring after %s, got %s", proxyOccupy.ExpiresAt, renewedOccupy.ExpiresAt)
+	}
+	if renewedOccupy.Proxy.ID != proxyOccupy.Proxy.ID {
+		t.Fatalf("expected proxy %d, got %d", proxyOccupy.Proxy.ID, renewedOccupy.Proxy.ID)
+	}
+
+	if err := repo.ReleaseProxy(ctx, proxyOccupy.Key, nil, domain.ProxyScoring{Decay: 0.5}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.RenewProxyOccupy(ctx, proxyOccupy.Key); !errors.Is(err, usecase.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for released occupy, got %v", err)
+	}
+}
+
+func TestPostgresProxyRepository_OccupyMaxOccupies(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestProxyRepository(t)
+	tag := newTestTag()
+	createTestProxies(t, repo, tag, 1, func(_ int, proxy *domain.Proxy) {
+		proxy.MaxOccupies = 2
+	})
+
+	opts := domain.OccupyOptions{TTL: time.Minute, TagFilter: domain.TagFilter{AllTags: []string{tag}}}
+	for i := 0; i < 2; i++ {
+		if _, err := repo.OccupyMostAvailableProxy(ctx, opts, firstPicker{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := repo.OccupyMostAvailableProxy(ctx, opts, firstPicker{}); !errors.Is(err, usecase.ErrProxiesSaturated) {
+		t.Fatalf("expected ErrProxiesSaturated, got %v", err)
+	}
+
+	opts.TagFilter.AllTags = []string{newTestTag()}
+	if _, err := repo.OccupyMostAvailableProxy(ctx, opts, firstPicker{}); !errors.Is(err, usecase.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPostgresProxyRepository_OccupyExclusive(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestProxyRepository(t)
+	tag := newTestTag()
+	createTestProxies(t, repo, tag, 1, nil)
+
+	shared := domain.OccupyOptions{TTL: time.Minute, TagFilter: domain.TagFilter{AllTags: []string{tag}}}
+	exclusive := shared
+	exclusive.Exclusive = true
+
+	sharedOccupy, err := repo.OccupyMostAvailableProxy(ctx, shared, firstPicker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.OccupyMostAvailableProxy(ctx, exclusive, firstPicker{}); !errors.Is(err, usecase.ErrProxiesSaturated) {
+		t.Fatalf("expected ErrProxiesSaturated for exclusive occupy of shared proxy, got %v", err)
+	}
+
+	if err := repo.ReleaseProxy(ctx, sharedOccupy.Key, nil, domain.ProxyScoring{Decay: 0.5}); err != nil {
+		t.Fatal(err)
+	}
+	exclusiveOccupy, err := repo.OccupyMostAvailableProxy(ctx, exclusive, firstPicker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exclusiveOccupy.Exclusive {
+		t.Fatal("expected exclusive occupy")
+	}
+
+	if _, err := repo.OccupyMostAvailableProxy(ctx, shared, firstPicker{}); !errors.Is(err, usecase.ErrProxiesSaturated) {
+		t.Fatalf("expected ErrProxiesSaturated for shared occupy of exclusively occupied proxy, got %v", err)
+	}
+}
+
+func TestPostgresProxyRepository_OccupyProxies(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestProxyRepository(t)
+	tag := newTestTag()
+	// The first two proxies share host
+	createTestProxies(t, repo, tag, 3, func(i int, proxy *domain.Proxy) {
+		if i < 2 {
+			proxy.Host, proxy.Port = tag+".example.com", 8080+int64(i)
+		}
+	})
+
+	opts := domain.BatchOccupyOptions{
+		OccupyOptions: domain.OccupyOptions{TTL: time.Minute, Exclusive: true, TagFilter: domain.TagFilter{AllTags: []string{tag}}},
+		Count:         3,
+		DistinctHosts: true,
+	}
+	if _, err := repo.OccupyProxies(ctx, opts, firstPicker{}); !errors.Is(err, usecase.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for 3 distinct hosts of 2, got %v", err)
+	}
+
+	opts.BestEffort = true
+	proxyOccupies, err := repo.OccupyProxies(ctx, opts, firstPicker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proxyOccupies) != 2 || proxyOccupies[0].Proxy.Host == proxyOccupies[1].Proxy.Host {
+		t.Fatalf("expected 2 occupies of distinct hosts, got %+v", proxyOccupies)
+	}
+	for _, proxyOccupy := range proxyOccupies {
+		if err := repo.ReleaseProxy(ctx, proxyOccupy.Key, nil, domain.ProxyScoring{Decay: 0.5}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Failed all-or-nothing occupy must not leave occupies, otherwise exclusive occupy of all proxies fails
+	opts.BestEffort, opts.DistinctHosts = false, false
+	proxyOccupies, err = repo.OccupyProxies(ctx, opts, firstPicker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[int64]bool)
+	for _, proxyOccupy := range proxyOccupies {
+		if seen[proxyOccupy.Proxy.ID] {
+			t.Fatalf("proxy %d is occupied twice", proxyOccupy.Proxy.ID)
+		}
+		seen[proxyOccupy.Proxy.ID] = true
+	}
+	if len(seen) != 3 {
+		t.Fatalf("expected 3 occupies, got %d", len(seen))
+	}
+}
+
+func TestPostgresProxyRepository_BanProxy(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestProxyRepository(t)
+	tag := newTestTag()
+	createTestProxies(t, repo, tag, 1, nil)
+
+	opts := domain.OccupyOptions{TTL: time.Minute, TagFilter: domain.TagFilter{AllTags: []string{tag}}, TargetDomain: "example.com"}
+	proxyOccupy, err := repo.OccupyMostAvailableProxy(ctx, opts, firstPicker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ban, err := repo.BanProxy(ctx, proxyOccupy.Key, "example.com", "captcha", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ban.ProxyID != proxyOccupy.Proxy.ID || !ban.BannedUntil.After(time.Now()) {
+		t.Fatalf("unexpected ban %+v", ban)
+	}
+	if err := repo.ReleaseProxy(ctx, proxyOccupy.Key, nil, domain.ProxyScoring{Decay: 0.5}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.BanProxy(ctx, proxyOccupy.Key, "example.com", "captcha", time.Minute); !errors.Is(err, usecase.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for released occupy, got %v", err)
+	}
+
+	if _, err := repo.OccupyMostAvailableProxy(ctx, opts, firstPicker{}); !errors.Is(err, usecase.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for banned domain, got %v", err)
+	}
+
+	opts.TargetDomain = "example.org"
+	if _, err := repo.OccupyMostAvailableProxy(ctx, opts, firstPicker{}); err != nil {
+		t.Fatalf("proxy banned for another domain is not occupied: %v", err)
+	}
+}
+
+func TestPostgresProxyRepository_OccupyTagFilter(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestProxyRepository(t)
+	tag := newTestTag()
+	tagA, tagB := tag+"-a", tag+"-b"
+	proxies := createTestProxies(t, repo, tag, 3, func(i int, proxy *domain.Proxy) {
+		proxy.Tags = [][]string{{tag, tagA}, {tag, tagB}, {tag, tagA, tagB}}[i]
+	})
+
+	tests := []struct {
+		name   string
+		filter domain.TagFilter
+		want   []int64
+	}{
+		{name: "all", filter: domain.TagFilter{AllTags: []string{tag, tagA}}, want: []int64{proxies[0].ID, proxies[2].ID}},
+		{name: "any", filter: domain.TagFilter{AnyTags: []string{tagA, tagB}}, want: []int64{proxies[0].ID, proxies[1].ID, proxies[2].ID}},
+		{name: "any and all", filter: domain.TagFilter{AnyTags: []string{tagB}, AllTags: []string{tagA}}, want: []int64{proxies[2].ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := domain.BatchOccupyOptions{
+				OccupyOptions: domain.OccupyOptions{TTL: time.Minute, TagFilter: tt.filter},
+				Count:         int64(len(proxies)),
+				BestEffort:    true,
+			}
+			proxyOccupies, err := repo.OccupyProxies(ctx, opts, firstPicker{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []int64
+			for _, proxyOccupy := range proxyOccupies {
+				got = append(got, proxyOccupy.Proxy.ID)
+				if err := repo.ReleaseProxy(ctx, proxyOccupy.Key, nil, domain.ProxyScoring{Decay: 0.5}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("expected proxies %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPostgresProxyRepository_OccupyPoolProxy(t *testing.T) {
+	ctx := context.Background()
+	repo, pgxPool := newTestProxyRepository(t)
+	poolRepo := repository.NewPostgresPoolRepository(pgxPool)
+	tag := newTestTag()
+	proxies := createTestProxies(t, repo, tag, 2, nil)
+
+	pool, err := poolRepo.CreatePool(ctx, domain.Pool{Name: tag, MaxOccupies: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := poolRepo.DeletePool(context.Background(), pool.Name); err != nil {
+			t.Error(err)
+		}
+	})
+	if missing, err := poolRepo.AddPoolProxies(ctx, pool.Name, []int64{proxies[1].ID}); err != nil || len(missing) > 0 {
+		t.Fatalf("failed to add proxy to pool, missing %v: %v", missing, err)
+	}
+
+	opts := domain.OccupyOptions{TTL: time.Minute, TagFilter: domain.TagFilter{AllTags: []string{tag}}, PoolID: pool.ID, DefaultMaxOccupies: pool.MaxOccupies}
+	proxyOccupy, err := repo.OccupyMostAvailableProxy(ctx, opts, firstPicker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proxyOccupy.Proxy.ID != proxies[1].ID {
+		t.Fatalf("expected pool proxy %d, got %d", proxies[1].ID, proxyOccupy.Proxy.ID)
+	}
+
+	// Pool max occupies applies to proxies without own limit, the proxy out of pool is not occupied
+	if _, err := repo.OccupyMostAvailableProxy(ctx, opts, firstPicker{}); !errors.Is(err, usecase.ErrProxiesSaturated) {
+		t.Fatalf("expected ErrProxiesSaturated, got %v", err)
+	}
+}
+
+func TestPostgresProxyRepository_GetProxyList(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestProxyRepository(t)
+	tag := newTestTag()
+	// Later created proxies expire earlier
+	proxies := createTestProxies(t, repo, tag, 3, func(i int, proxy *domain.Proxy) {
+		proxy.Port = 8080 + int64(i)
+		proxy.ExpirationDate = time.Now().Add(time.Hour * time.Duration(24*(3-i)))
+	})
+
+	proxyOccupy, err := repo.OccupyMostAvailableProxy(ctx, domain.OccupyOptions{TTL: time.Minute, TagFilter: domain.TagFilter{AllTags: []string{tag}}}, firstPicker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	occupied := true
+	expiresBefore := time.Now().Add(time.Hour * 60)
+	tests := []struct {
+		name   string
+		filter domain.ProxyFilter
+		sort   domain.ProxySort
+		want   []int64
+	}{
+		{name: "all", want: []int64{proxies[0].ID, proxies[1].ID, proxies[2].ID}},
+		{name: "by id desc", sort: domain.ProxySort{Field: domain.SortByID, Desc: true}, want: []int64{proxies[2].ID, proxies[1].ID, proxies[0].ID}},
+		{name: "by expiration date", sort: domain.ProxySort{Field: domain.SortByExpirationDate}, want: []int64{proxies[2].ID, proxies[1].ID, proxies[0].ID}},
+		{name: "port", filter: domain.ProxyFilter{Port: 8081}, want: []int64{proxies[1].ID}},
+		{name: "host", filter: domain.ProxyFilter{HostContains: strings.ToUpper(tag) + "-2"}, want: []int64{proxies[2].ID}},
+		{name: "expires before", filter: domain.ProxyFilter{ExpiresBefore: &expiresBefore}, want: []int64{proxies[1].ID, proxies[2].ID}},
+		{name: "occupied", filter: domain.ProxyFilter{Occupied: &occupied}, want: []int64{proxyOccupy.Proxy.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.AllTags = []string{tag}
+			proxyList, err := repo.GetProxyList(ctx, tt.filter, tt.sort, domain.ProxyPage{Limit: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []int64
+			for _, proxy := range proxyList.Proxies {
+				got = append(got, proxy.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("expected proxies %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("cursor", func(t *testing.T) {
+		filter := domain.ProxyFilter{TagFilter: domain.TagFilter{AllTags: []string{tag}}}
+		sort := domain.ProxySort{Field: domain.SortByExpirationDate, Desc: true}
+
+		var got []int64
+		page := domain.ProxyPage{Limit: 2}
+		for {
+			proxyList, err := repo.GetProxyList(ctx, filter, sort, page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, proxy := range proxyList.Proxies {
+				got = append(got, proxy.ID)
+			}
+			if proxyList.NextCursor == "" {
+				break
+			}
+			page.Cursor = proxyList.NextCursor
+		}
+
+		want := []int64{proxies[0].ID, proxies[1].ID, proxies[2].ID}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("expected proxies %v, got %v", want, got)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"proxy_manager/internal/domain"
+	"strings"
+)
+
+// apiKeyTokenPrefix marks tokens of API keys, so they are easy to find in leaked configs.
+const apiKeyTokenPrefix = "pm_"
+
+// apiKeyVisiblePrefixLen is a length of token beginning, that is stored as is to recognize API key.
+const apiKeyVisiblePrefixLen = len(apiKeyTokenPrefix) + 8
+
+// Auth authenticates API clients by bearer tokens of API keys.
+// Tokens are random, so their SHA-256 hashes are stored instead of slow password hashes.
+type Auth struct {
+	apiKeyRepo   domain.APIKeyRepository
+	enabled      bool
+	adminKeyHash []byte // hash of bootstrap admin token, nil if it isn't set
+}
+
+// NewAuth creates Auth, if it is disabled, every client is authenticated as admin.
+// adminToken, if not empty, is accepted as token of admin key, so the first API keys can be created.
+func NewAuth(apiKeyRepo domain.APIKeyRepository, enabled bool, adminToken string) Auth {
+	a := Auth{apiKeyRepo: apiKeyRepo, enabled: enabled}
+	if adminToken != "" {
+		a.adminKeyHash = hashAPIKeyToken(adminToken)
+	}
+	return a
+}
+
+func hashAPIKeyToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
+
+// Authenticate returns API key with given token, ErrUnauthorized if there is no such active key.
+func (a Auth) Authenticate(ctx context.Context, token string) (domain.APIKey, error) {
+	if !a.enabled {
+		return domain.APIKey{Name: "anonymous", Role: domain.RoleAdmin}, nil
+	}
+	if token == "" {
+		return domain.APIKey{}, ErrUnauthorized
+	}
+
+	hash := hashAPIKeyToken(token)
+	if a.adminKeyHash != nil && subtle.ConstantTimeCompare(hash, a.adminKeyHash) == 1 {
+		return domain.APIKey{Name: "admin", Role: domain.RoleAdmin}, nil
+	}
+	if !strings.HasPrefix(token, apiKeyTokenPrefix) {
+		return domain.APIKey{}, ErrUnauthorized
+	}
+
+	key, err := a.apiKeyRepo.GetActiveAPIKey(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ErrAPIKeyNotFound) {
+			return domain.APIKey{}, ErrUnauthorized
+		}
+		return domain.APIKey{}, errors.Join(ErrInRepo, err)
+	}
+	return key, nil
+}
+
+// CreateAPIKey creates API key and returns it with its token, which is not stored and can't be shown again.
+func (a Auth) CreateAPIKey(ctx context.Context, name string, role string) (domain.APIKey, string, error) {
+	key := domain.APIKey{Name: name, Role: role}
+	if err := key.Validate(); err != nil {
+		return domain.APIKey{}, "", errors.Join(ErrInvalidData, err)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return domain.APIKey{}, "", err
+	}
+	token := apiKeyTokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	key.Prefix = token[:apiKeyVisiblePrefixLen]
+	key.Hash = hashAPIKeyToken(token)
+
+	key, err := a.apiKeyRepo.CreateAPIKey(ctx, key)
+	if err != nil {
+		return domain.APIKey{}, "", errors.Join(ErrInRepo, err)
+	}
+	return key, token, nil
+}
+
+func (a Auth) GetAPIKeyList(ctx context.Context) ([]domain.APIKey, error) {
+	keys, err := a.apiKeyRepo.GetAPIKeyList(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrInRepo, err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes API key, its token isn't accepted anymore.
+func (a Auth) RevokeAPIKey(ctx context.Context, keyID int64) error {
+	if keyID <= 0 {
+		return errors.Join(ErrInvalidData, errors.New("api key id must be > 0"))
+	}
+
+	if err := a.apiKeyRepo.RevokeAPIKey(ctx, keyID); err != nil {
+		if errors.Is(err, ErrAPIKeyNotFound) {
+			return err
+		}
+		return errors.Join(ErrInRepo, err)
+	}
+	return nil
+}
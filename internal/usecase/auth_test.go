@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"proxy_manager/internal/domain"
+	"testing"
+	"time"
+)
+
+// memoryAPIKeyRepo keeps API keys in memory.
+type memoryAPIKeyRepo struct {
+	keys []domain.APIKey
+}
+
+func (r *memoryAPIKeyRepo) CreateAPIKey(_ context.Context, key domain.APIKey) (domain.APIKey, error) {
+	key.ID, key.CreatedAt = int64(len(r.keys)+1), time.Now()
+	r.keys = append(r.keys, key)
+	return key, nil
+}
+
+func (r *memoryAPIKeyRepo) GetActiveAPIKey(_ context.Context, hash []byte) (domain.APIKey, error) {
+	for _, key := range r.keys {
+		if bytes.Equal(key.Hash, hash) && key.RevokedAt == nil {
+			return key, nil
+		}
+	}
+	return domain.APIKey{}, ErrAPIKeyNotFound
+}
+
+func (r *memoryAPIKeyRepo) GetAPIKeyList(_ context.Context) ([]domain.APIKey, error) {
+	return r.keys, nil
+}
+
+func (r *memoryAPIKeyRepo) RevokeAPIKey(_ context.Context, keyID int64) error {
+	if keyID > int64(len(r.keys)) {
+		return ErrAPIKeyNotFound
+	}
+	now := time.Now()
+	r.keys[keyID-1].RevokedAt = &now
+	return nil
+}
+
+func TestAuth(t *testing.T) {
+	ctx := context.Background()
+	auth := NewAuth(&memoryAPIKeyRepo{}, true, "admin-token")
+
+	if _, _, err := auth.CreateAPIKey(ctx, "scraper", "owner"); !errors.Is(err, ErrInvalidData) {
+		t.Fatalf("expected invalid data, got %v", err)
+	}
+
+	key, token, err := auth.CreateAPIKey(ctx, "scraper", domain.RoleConsumer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.Prefix == "" || token[:len(key.Prefix)] != key.Prefix || bytes.Contains(key.Hash, []byte(token)) {
+		t.Fatalf("unexpected api key %+v for token %q", key, token)
+	}
+
+	got, err := auth.Authenticate(ctx, token)
+	if err != nil || got.ID != key.ID || !got.Can(domain.PermissionOccupy) || got.Can(domain.PermissionRead) {
+		t.Fatalf("expected consumer key %d, got %+v, %v", key.ID, got, err)
+	}
+
+	admin, err := auth.Authenticate(ctx, "admin-token")
+	if err != nil || !admin.Can(domain.PermissionWrite) {
+		t.Fatalf("expected admin key, got %+v, %v", admin, err)
+	}
+
+	for _, token := range []string{"", "pm_unknown", "unknown"} {
+		if _, err := auth.Authenticate(ctx, token); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected unauthorized for %q, got %v", token, err)
+		}
+	}
+
+	if err := auth.RevokeAPIKey(ctx, key.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := auth.Authenticate(ctx, token); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected unauthorized for revoked key, got %v", err)
+	}
+	if err := auth.RevokeAPIKey(ctx, 100); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Fatalf("expected api key not found, got %v", err)
+	}
+
+	disabled := NewAuth(nil, false, "")
+	if anonymous, err := disabled.Authenticate(ctx, ""); err != nil || !anonymous.Can(domain.PermissionWrite) {
+		t.Fatalf("expected admin access with disabled auth, got %+v, %v", anonymous, err)
+	}
+}
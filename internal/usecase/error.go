@@ -1,9 +1,35 @@
 package usecase
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrNotFound    = errors.New("proxy not found")
 	ErrInRepo      = errors.New("error in repo")
 	ErrInvalidData = errors.New("invalid data")
+
+	ErrAlreadyExists = errors.New("already exists")
+	ErrPoolNotFound  = errors.New("pool not found")
+
+	ErrProxiesSaturated = errors.New("all matching proxies are occupied to their limit")
+	ErrVersionMismatch  = errors.New("proxy was changed, its version doesn't match")
+
+	ErrUnauthorized   = errors.New("missing, invalid or revoked api key")
+	ErrAPIKeyNotFound = errors.New("api key not found")
 )
+
+// DuplicateProxyError is returned when proxy with the same protocol, username, host and port already exists.
+// It matches ErrAlreadyExists.
+type DuplicateProxyError struct {
+	ProxyID int64 // ID of existing proxy
+}
+
+func (e *DuplicateProxyError) Error() string {
+	return fmt.Sprintf("proxy already exists with id %d", e.ProxyID)
+}
+
+func (e *DuplicateProxyError) Unwrap() error {
+	return ErrAlreadyExists
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"proxy_manager/internal/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pagedProxyRepo returns proxies ordered by ID by pages of 2 proxies.
+type pagedProxyRepo struct {
+	domain.ProxyRepository
+	proxies []domain.Proxy
+}
+
+func (r *pagedProxyRepo) GetProxyList(_ context.Context, _ domain.ProxyFilter, sort domain.ProxySort, page domain.ProxyPage) (domain.ProxyList, error) {
+	var afterID int64
+	if page.Cursor != "" {
+		cursor, err := domain.DecodeProxyCursor(page.Cursor, sort)
+		if err != nil {
+			return domain.ProxyList{}, err
+		}
+		afterID = cursor.ID
+	}
+
+	var list domain.ProxyList
+	for _, proxy := range r.proxies {
+		if proxy.ID <= afterID {
+			continue
+		}
+		if len(list.Proxies) == 2 {
+			list.NextCursor = domain.NewProxyCursor(sort, list.Proxies[1]).Encode()
+			break
+		}
+		list.Proxies = append(list.Proxies, proxy)
+	}
+	return list, nil
+}
+
+func TestExportProxies(t *testing.T) {
+	repo := &pagedProxyRepo{}
+	for id := int64(1); id <= 5; id++ {
+		repo.proxies = append(repo.proxies, domain.Proxy{ID: id, Protocol: "http", Host: "127.0.0.1", Port: 8000 + id, Username: "login123", Password: "qwerty"})
+	}
+	u := New(repo, nil, time.Minute, time.Hour, time.Minute, StrategyLeastOccupied, domain.ProxyScoring{Decay: 0.5}, time.Hour)
+
+	var buf bytes.Buffer
+	if err := u.ExportProxies(context.Background(), &buf, domain.ProxyFilter{}, domain.ProxySort{}, domain.ExportFormatColon, true); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 || lines[0] != "127.0.0.1:8001:login123:qwerty" || lines[4] != "127.0.0.1:8005:login123:qwerty" {
+		t.Fatalf("expected all 5 proxies, got %q", lines)
+	}
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"proxy_manager/internal/domain"
+	"proxy_manager/pkg/logger"
+	"sync"
+	"time"
+)
+
+// HealthChecker periodically checks all enabled proxies and stores results.
+// Proxies that failed maxFailures checks in a row are not occupied, until they pass a check again.
+type HealthChecker struct {
+	proxyRepo   domain.ProxyRepository
+	checker     domain.ProxyChecker
+	interval    time.Duration
+	maxFailures int64
+	workers     int
+	l           logger.Interface
+}
+
+func NewHealthChecker(proxyRepo domain.ProxyRepository, checker domain.ProxyChecker, interval time.Duration,
+	maxFailures int64, workers int, l logger.Interface,
+) HealthChecker {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return HealthChecker{
+		proxyRepo:   proxyRepo,
+		checker:     checker,
+		interval:    interval,
+		maxFailures: maxFailures,
+		workers:     workers,
+		l:           l,
+	}
+}
+
+func (h HealthChecker) Start(ctx context.Context) {
+	go h.run(ctx)
+}
+
+func (h HealthChecker) run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	defer h.l.Info("Proxy health checker exited!")
+	h.l.Info("Started proxy health checker")
+
+	for {
+		h.CheckAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll checks every enabled proxy once, using at most h.workers concurrent checks.
+func (h HealthChecker) CheckAll(ctx context.Context) {
+	proxies, err := h.proxyRepo.GetProxiesToCheck(ctx)
+	if err != nil {
+		h.l.Error("HealthChecker - CheckAll - %s", err)
+		return
+	}
+
+	proxiesChan := make(chan domain.Proxy)
+	wg := sync.WaitGroup{}
+	for i := 0; i < h.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for proxy := range proxiesChan {
+				h.check(ctx, proxy)
+			}
+		}()
+	}
+
+feed:
+	for _, proxy := range proxies {
+		select {
+		case <-ctx.Done():
+			break feed
+		case proxiesChan <- proxy:
+		}
+	}
+	close(proxiesChan)
+	wg.Wait()
+}
+
+func (h HealthChecker) check(ctx context.Context, proxy domain.Proxy) {
+	latency, err := h.checker.Check(ctx, proxy)
+	if ctx.Err() != nil {
+		// Check was interrupted by shutdown, its result says nothing about proxy
+		return
+	}
+
+	check := domain.ProxyCheck{
+		ProxyID:   proxy.ID,
+		Success:   err == nil,
+		Latency:   latency,
+		CheckedAt: time.Now(),
+	}
+	if err != nil {
+		check.Error = err.Error()
+		h.l.Debug("HealthChecker - proxy %d check failed - %s", proxy.ID, err)
+	}
+
+	if err := h.proxyRepo.SaveProxyCheck(ctx, check, h.maxFailures); err != nil {
+		h.l.Error("HealthChecker - SaveProxyCheck - %s", err)
+	}
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"proxy_manager/internal/domain"
+	"testing"
+)
+
+func TestRejectImportDuplicates(t *testing.T) {
+	imported := []domain.ImportedProxy{
+		{Line: 1, Proxy: domain.Proxy{Protocol: "http", Host: "127.0.0.1", Port: 8080, Username: "a"}},
+		{Line: 3, Proxy: domain.Proxy{Protocol: "http", Host: "127.0.0.1", Port: 8080, Username: "b"}},
+		{Line: 4, Proxy: domain.Proxy{Protocol: "http", Host: "127.0.0.1", Port: 8080, Username: "a", Password: "other"}},
+	}
+	importErrors := []domain.ImportError{{Line: 2, Error: "invalid"}, {Line: 5, Error: "invalid"}}
+
+	unique, importErrors := rejectImportDuplicates(imported, importErrors)
+	if len(unique) != 2 || unique[0].Line != 1 || unique[1].Line != 3 {
+		t.Fatalf("expected lines 1 and 3 to be kept, got %+v", unique)
+	}
+
+	wantLines := []int{2, 4, 5}
+	if len(importErrors) != len(wantLines) {
+		t.Fatalf("expected errors on lines %v, got %+v", wantLines, importErrors)
+	}
+	for i, line := range wantLines {
+		if importErrors[i].Line != line {
+			t.Fatalf("expected errors on lines %v, got %+v", wantLines, importErrors)
+		}
+	}
+	if importErrors[1].Error != "duplicates line 1" {
+		t.Fatalf("unexpected error %q", importErrors[1].Error)
+	}
+}
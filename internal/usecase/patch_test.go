@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"proxy_manager/internal/domain"
+	"testing"
+	"time"
+)
+
+// lockingProxyRepo modifies single proxy like repository, that locks it between read and update.
+type lockingProxyRepo struct {
+	domain.ProxyRepository
+	proxy       domain.Proxy
+	invalidated bool
+}
+
+func (r *lockingProxyRepo) ModifyProxy(_ context.Context, proxyID int64, version int64, modify domain.ProxyModifier) (domain.Proxy, error) {
+	if proxyID != r.proxy.ID {
+		return domain.Proxy{}, ErrNotFound
+	}
+	if version != 0 && version != r.proxy.Version {
+		return domain.Proxy{}, ErrVersionMismatch
+	}
+
+	proxy, invalidateOccupies, err := modify(r.proxy)
+	if err != nil {
+		return domain.Proxy{}, err
+	}
+	proxy.Version = r.proxy.Version + 1
+	r.proxy, r.invalidated = proxy, invalidateOccupies
+	return proxy, nil
+}
+
+func TestPatchProxy(t *testing.T) {
+	ctx := context.Background()
+
+	newUseCase := func() (UseCase, *lockingProxyRepo) {
+		repo := &lockingProxyRepo{
+			proxy: domain.Proxy{ID: 1, Protocol: "http", Host: "127.0.0.1", Port: 8080, ExpirationDate: time.Now().Add(time.Hour), Version: 2},
+		}
+		return New(repo, nil, time.Minute, time.Hour, time.Minute, StrategyLeastOccupied, domain.ProxyScoring{Decay: 0.5}, time.Hour), repo
+	}
+
+	port := int64(3128)
+	weight := int64(5)
+
+	tests := []struct {
+		name            string
+		version         int64
+		patch           domain.ProxyPatch
+		wantErr         error
+		wantInvalidated bool
+	}{
+		{name: "without version", patch: domain.ProxyPatch{Weight: &weight}},
+		{name: "matching version", version: 2, patch: domain.ProxyPatch{Port: &port}, wantInvalidated: true},
+		{name: "stale version", version: 1, patch: domain.ProxyPatch{Port: &port}, wantErr: ErrVersionMismatch},
+		{name: "invalid patch", patch: domain.ProxyPatch{Port: new(int64)}, wantErr: ErrInvalidData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, repo := newUseCase()
+			patched, err := u.PatchProxy(ctx, 1, tt.version, tt.patch)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if patched.Version != 3 || repo.invalidated != tt.wantInvalidated {
+				t.Fatalf("unexpected patched proxy %+v, occupies invalidated %t", patched, repo.invalidated)
+			}
+		})
+	}
+}
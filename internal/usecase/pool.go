@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"proxy_manager/internal/domain"
+	"time"
+)
+
+func (u *UseCase) CreatePool(ctx context.Context, pool domain.Pool) (domain.Pool, error) {
+	if err := u.validatePool(pool); err != nil {
+		return domain.Pool{}, errors.Join(ErrInvalidData, err)
+	}
+
+	pool, err := u.poolRepo.CreatePool(ctx, pool)
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			return domain.Pool{}, err
+		}
+		return domain.Pool{}, errors.Join(ErrInRepo, err)
+	}
+	return pool, nil
+}
+
+func (u *UseCase) GetPool(ctx context.Context, name string) (domain.Pool, error) {
+	pool, err := u.poolRepo.GetPool(ctx, name)
+	if err != nil {
+		if errors.Is(err, ErrPoolNotFound) {
+			return domain.Pool{}, err
+		}
+		return domain.Pool{}, errors.Join(ErrInRepo, err)
+	}
+	return pool, nil
+}
+
+func (u *UseCase) UpdatePool(ctx context.Context, pool domain.Pool) (domain.Pool, error) {
+	if err := u.validatePool(pool); err != nil {
+		return domain.Pool{}, errors.Join(ErrInvalidData, err)
+	}
+
+	pool, err := u.poolRepo.UpdatePool(ctx, pool)
+	if err != nil {
+		if errors.Is(err, ErrPoolNotFound) {
+			return domain.Pool{}, err
+		}
+		return domain.Pool{}, errors.Join(ErrInRepo, err)
+	}
+	return pool, nil
+}
+
+func (u *UseCase) DeletePool(ctx context.Context, name string) error {
+	if err := u.poolRepo.DeletePool(ctx, name); err != nil {
+		if errors.Is(err, ErrPoolNotFound) {
+			return err
+		}
+		return errors.Join(ErrInRepo, err)
+	}
+	return nil
+}
+
+func (u *UseCase) GetPoolList(ctx context.Context) ([]domain.Pool, error) {
+	pools, err := u.poolRepo.GetPoolList(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrInRepo, err)
+	}
+	return pools, nil
+}
+
+func (u *UseCase) AddPoolProxies(ctx context.Context, name string, proxyIDs []int64) error {
+	if len(proxyIDs) == 0 {
+		return errors.Join(ErrInvalidData, errors.New("proxy IDs can't be empty"))
+	}
+
+	missingIDs, err := u.poolRepo.AddPoolProxies(ctx, name, proxyIDs)
+	if err != nil {
+		if errors.Is(err, ErrPoolNotFound) {
+			return err
+		}
+		return errors.Join(ErrInRepo, err)
+	}
+	if len(missingIDs) > 0 {
+		return errors.Join(ErrInvalidData, fmt.Errorf("proxies %v don't exist", missingIDs))
+	}
+	return nil
+}
+
+func (u *UseCase) RemovePoolProxies(ctx context.Context, name string, proxyIDs []int64) error {
+	if len(proxyIDs) == 0 {
+		return errors.Join(ErrInvalidData, errors.New("proxy IDs can't be empty"))
+	}
+
+	if err := u.poolRepo.RemovePoolProxies(ctx, name, proxyIDs); err != nil {
+		if errors.Is(err, ErrPoolNotFound) {
+			return err
+		}
+		return errors.Join(ErrInRepo, err)
+	}
+	return nil
+}
+
+// OccupyPoolProxy occupies proxy of pool with given name, using pool settings instead of server defaults.
+// Settings requested in opts take precedence over pool settings.
+func (u *UseCase) OccupyPoolProxy(ctx context.Context, name string, opts domain.OccupyOptions) (domain.ProxyOccupy, error) {
+	pool, err := u.GetPool(ctx, name)
+	if err != nil {
+		return domain.ProxyOccupy{}, err
+	}
+
+	opts.PoolID = pool.ID
+	opts.DefaultMaxOccupies = pool.MaxOccupies
+	if opts.TTL == 0 {
+		opts.TTL = time.Second * time.Duration(pool.OccupyTTL)
+	}
+	if opts.Strategy == "" {
+		opts.Strategy = pool.Strategy
+	}
+
+	return u.OccupyMostAvailableProxy(ctx, opts)
+}
+
+func (u *UseCase) validatePool(pool domain.Pool) error {
+	if err := pool.Validate(); err != nil {
+		return err
+	}
+
+	if pool.Strategy != "" {
+		return ValidateStrategy(pool.Strategy)
+	}
+	return nil
+}
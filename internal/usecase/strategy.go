@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"fmt"
+	"math/rand"
+	"proxy_manager/internal/domain"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Names of proxy selection strategies.
+const (
+	StrategyLeastOccupied     = "least_occupied"
+	StrategyRoundRobin        = "round_robin"
+	StrategyLeastRecentlyUsed = "least_recently_used"
+	StrategyRandom            = "random"
+	StrategyWeightedRandom    = "weighted_random"
+	StrategyLowestLatency     = "lowest_latency"
+	StrategyBestScore         = "best_score"
+)
+
+// Strategies contains names of all supported proxy selection strategies.
+var Strategies = []string{
+	StrategyLeastOccupied,
+	StrategyRoundRobin,
+	StrategyLeastRecentlyUsed,
+	StrategyRandom,
+	StrategyWeightedRandom,
+	StrategyLowestLatency,
+	StrategyBestScore,
+}
+
+// newStrategies creates one picker per strategy, pickers are stateful and must be shared between occupies.
+func newStrategies() map[string]domain.ProxyPicker {
+	return map[string]domain.ProxyPicker{
+		StrategyLeastOccupied:     leastOccupiedPicker{},
+		StrategyRoundRobin:        &roundRobinPicker{},
+		StrategyLeastRecentlyUsed: leastRecentlyUsedPicker{},
+		StrategyRandom:            randomPicker{},
+		StrategyWeightedRandom:    weightedRandomPicker{},
+		StrategyLowestLatency:     lowestLatencyPicker{},
+		StrategyBestScore:         bestScorePicker{},
+	}
+}
+
+// ValidateStrategy returns error if there is no strategy with given name.
+func ValidateStrategy(name string) error {
+	for _, strategy := range Strategies {
+		if name == strategy {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid strategy, allowed strategies: (%s)", strings.Join(Strategies, ", "))
+}
+
+// leastOccupiedPicker picks proxy with the least occupies count, the first one on tie.
+type leastOccupiedPicker struct{}
+
+func (leastOccupiedPicker) Pick(candidates []domain.Proxy) domain.Proxy {
+	return pickMin(candidates, func(a, b domain.Proxy) bool {
+		return a.OccupiesCount < b.OccupiesCount
+	})
+}
+
+func (leastOccupiedPicker) PickOrder() []string {
+	return []string{domain.PickByOccupiesCount}
+}
+
+// roundRobinPicker picks proxies in turn by ID. Pick keeps the last picked ID in process,
+// while repository keeps it per pool in database, so the turn is shared by all replicas.
+type roundRobinPicker struct {
+	mu     sync.Mutex
+	lastID int64
+}
+
+func (p *roundRobinPicker) Pick(candidates []domain.Proxy) domain.Proxy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	i := sort.Search(len(candidates), func(i int) bool {
+		return candidates[i].ID > p.lastID
+	})
+	if i == len(candidates) {
+		i = 0
+	}
+
+	p.lastID = candidates[i].ID
+	return candidates[i]
+}
+
+func (*roundRobinPicker) PickOrder() []string {
+	return []string{domain.PickByRoundRobin}
+}
+
+// leastRecentlyUsedPicker picks proxy, that was occupied the longest time ago, never occupied proxies first.
+type leastRecentlyUsedPicker struct{}
+
+func (leastRecentlyUsedPicker) Pick(candidates []domain.Proxy) domain.Proxy {
+	return pickMin(candidates, func(a, b domain.Proxy) bool {
+		if a.LastOccupiedAt == nil || b.LastOccupiedAt == nil {
+			return a.LastOccupiedAt == nil && b.LastOccupiedAt != nil
+		}
+		return a.LastOccupiedAt.Before(*b.LastOccupiedAt)
+	})
+}
+
+func (leastRecentlyUsedPicker) PickOrder() []string {
+	return []string{domain.PickByLastOccupiedAt}
+}
+
+// randomPicker picks random proxy.
+type randomPicker struct{}
+
+func (randomPicker) Pick(candidates []domain.Proxy) domain.Proxy {
+	return candidates[rand.Intn(len(candidates))] //nolint:gosec // no need in crypto rand
+}
+
+func (randomPicker) PickOrder() []string {
+	return []string{domain.PickByRandom}
+}
+
+// weightedRandomPicker picks random proxy with probability proportional to its weight.
+// If all proxies have zero weight, proxy is picked uniformly.
+type weightedRandomPicker struct{}
+
+func (weightedRandomPicker) Pick(candidates []domain.Proxy) domain.Proxy {
+	var total int64
+	for _, proxy := range candidates {
+		total += proxy.Weight
+	}
+	if total <= 0 {
+		return randomPicker{}.Pick(candidates)
+	}
+
+	r := rand.Int63n(total) //nolint:gosec // no need in crypto rand
+	for _, proxy := range candidates {
+		if r < proxy.Weight {
+			return proxy
+		}
+		r -= proxy.Weight
+	}
+	return candidates[len(candidates)-1]
+}
+
+func (weightedRandomPicker) PickOrder() []string {
+	return []string{domain.PickByWeight}
+}
+
+// lowestLatencyPicker picks proxy with the lowest latency of last health check,
+// not yet checked proxies are picked last, the least occupied one on tie.
+type lowestLatencyPicker struct{}
+
+func (lowestLatencyPicker) Pick(candidates []domain.Proxy) domain.Proxy {
+	return pickMin(candidates, func(a, b domain.Proxy) bool {
+		if a.Latency != b.Latency {
+			if a.Latency == 0 || b.Latency == 0 {
+				return b.Latency == 0
+			}
+			return a.Latency < b.Latency
+		}
+		return a.OccupiesCount < b.OccupiesCount
+	})
+}
+
+func (lowestLatencyPicker) PickOrder() []string {
+	return []string{domain.PickByLatency, domain.PickByOccupiesCount}
+}
+
+// bestScorePicker picks proxy with the highest success rate of reported outcomes, the least occupied one on tie.
+type bestScorePicker struct{}
+
+func (bestScorePicker) Pick(candidates []domain.Proxy) domain.Proxy {
+	return pickMin(candidates, func(a, b domain.Proxy) bool {
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.OccupiesCount < b.OccupiesCount
+	})
+}
+
+func (bestScorePicker) PickOrder() []string {
+	return []string{domain.PickByScore, domain.PickByOccupiesCount}
+}
+
+// pickMin returns the first minimal proxy according to less.
+func pickMin(candidates []domain.Proxy, less func(a, b domain.Proxy) bool) domain.Proxy {
+	best := candidates[0]
+	for _, proxy := range candidates[1:] {
+		if less(proxy, best) {
+			best = proxy
+		}
+	}
+	return best
+}
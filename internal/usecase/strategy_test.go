@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"proxy_manager/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestStrategies_Pick(t *testing.T) {
+	earlier := time.Now().Add(-time.Hour)
+	later := time.Now()
+
+	candidates := []domain.Proxy{
+		{ID: 1, OccupiesCount: 2, Latency: 0, Score: 0.5, LastOccupiedAt: &later},
+		{ID: 2, OccupiesCount: 1, Latency: 300, Score: 0.9, LastOccupiedAt: &earlier},
+		{ID: 3, OccupiesCount: 1, Latency: 100, Score: 0.9},
+	}
+
+	tests := []struct {
+		strategy string
+		want     int64
+	}{
+		{strategy: StrategyLeastOccupied, want: 2},
+		{strategy: StrategyLeastRecentlyUsed, want: 3},
+		{strategy: StrategyLowestLatency, want: 3},
+		{strategy: StrategyBestScore, want: 2},
+	}
+
+	strategies := newStrategies()
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			if got := strategies[tt.strategy].Pick(candidates); got.ID != tt.want {
+				t.Fatalf("expected proxy %d, got %d", tt.want, got.ID)
+			}
+		})
+	}
+}
+
+func TestStrategies_RoundRobin(t *testing.T) {
+	picker := newStrategies()[StrategyRoundRobin]
+	candidates := []domain.Proxy{{ID: 1}, {ID: 4}, {ID: 7}}
+
+	var picked []int64
+	for i := 0; i < 4; i++ {
+		picked = append(picked, picker.Pick(candidates).ID)
+	}
+
+	// Proxy 4 is gone, so the next one after 1 is 7
+	picked = append(picked, picker.Pick([]domain.Proxy{{ID: 1}, {ID: 7}}).ID)
+
+	want := []int64{1, 4, 7, 1, 7}
+	for i := range want {
+		if picked[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, picked)
+		}
+	}
+}
+
+func TestStrategies_WeightedRandom(t *testing.T) {
+	picker := newStrategies()[StrategyWeightedRandom]
+	candidates := []domain.Proxy{{ID: 1, Weight: 0}, {ID: 2, Weight: 5}}
+
+	for i := 0; i < 100; i++ {
+		if got := picker.Pick(candidates); got.ID != 2 {
+			t.Fatalf("proxy with zero weight picked")
+		}
+	}
+}
+
+func TestStrategies_PickOrder(t *testing.T) {
+	// Every strategy is picked by repository query, so occupy never loads all available proxies
+	for name, picker := range newStrategies() {
+		ordered, ok := picker.(domain.OrderedProxyPicker)
+		if !ok {
+			t.Fatalf("strategy %s is not ordered", name)
+		}
+		if len(ordered.PickOrder()) == 0 {
+			t.Fatalf("strategy %s: empty pick order", name)
+		}
+	}
+}
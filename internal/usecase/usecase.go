@@ -3,19 +3,54 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"proxy_manager/internal/domain"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
 )
 
 type UseCase struct {
-	proxyRepo domain.ProxyRepository
+	proxyRepo  domain.ProxyRepository
+	poolRepo   domain.PoolRepository
+	waiters    *occupyWaiters
+	strategies map[string]domain.ProxyPicker
+
+	occupyTTL       time.Duration
+	maxOccupyTTL    time.Duration
+	maxOccupyWait   time.Duration
+	defaultStrategy string
+	scoring         domain.ProxyScoring
+	banCooldown     time.Duration
 }
 
-func New(proxyRepo domain.ProxyRepository) UseCase {
-	return UseCase{proxyRepo: proxyRepo}
+// New creates UseCase, occupyTTL is used for occupies without requested TTL,
+// requested TTL is capped by maxOccupyTTL and requested wait for available proxy by maxOccupyWait.
+// defaultStrategy is used for occupies without requested strategy, it must be one of Strategies.
+// scoring describes how outcomes reported on release change proxy score.
+// banCooldown is used for bans without requested cooldown.
+func New(proxyRepo domain.ProxyRepository, poolRepo domain.PoolRepository, occupyTTL time.Duration, maxOccupyTTL time.Duration,
+	maxOccupyWait time.Duration, defaultStrategy string, scoring domain.ProxyScoring, banCooldown time.Duration,
+) UseCase {
+	return UseCase{
+		proxyRepo:       proxyRepo,
+		poolRepo:        poolRepo,
+		waiters:         newOccupyWaiters(),
+		strategies:      newStrategies(),
+		occupyTTL:       occupyTTL,
+		maxOccupyTTL:    maxOccupyTTL,
+		maxOccupyWait:   maxOccupyWait,
+		defaultStrategy: defaultStrategy,
+		scoring:         scoring,
+		banCooldown:     banCooldown,
+	}
 }
 
 func (u *UseCase) CreateProxy(ctx context.Context, proxy domain.Proxy) (domain.Proxy, error) {
 	proxy.ExpirationDate = proxy.ExpirationDate.UTC()
+	proxy.Tags = domain.NormalizeTags(proxy.Tags)
 
 	if err := proxy.Validate(); err != nil {
 		return domain.Proxy{}, errors.Join(ErrInvalidData, err)
@@ -23,13 +58,20 @@ func (u *UseCase) CreateProxy(ctx context.Context, proxy domain.Proxy) (domain.P
 
 	proxy, err := u.proxyRepo.CreateProxy(ctx, proxy)
 	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			return domain.Proxy{}, err
+		}
 		return domain.Proxy{}, errors.Join(ErrInRepo, err)
 	}
 	return proxy, nil
 }
 
-func (u *UseCase) UpdateProxy(ctx context.Context, updatedProxy domain.Proxy) (domain.Proxy, error) {
+// UpdateProxy updates proxy and releases all its occupies. If keepPassword is set, the stored password is kept
+// instead of updatedProxy.Password, so proxy read without credentials can be written back.
+// If updatedProxy.Version is not 0, proxy is updated only if it has this version.
+func (u *UseCase) UpdateProxy(ctx context.Context, updatedProxy domain.Proxy, keepPassword bool) (domain.Proxy, error) {
 	updatedProxy.ExpirationDate = updatedProxy.ExpirationDate.UTC()
+	updatedProxy.Tags = domain.NormalizeTags(updatedProxy.Tags)
 
 	if updatedProxy.ID <= 0 {
 		return domain.Proxy{}, errors.Join(ErrInvalidData, errors.New("ProxyID must be > 0"))
@@ -39,9 +81,19 @@ func (u *UseCase) UpdateProxy(ctx context.Context, updatedProxy domain.Proxy) (d
 		return domain.Proxy{}, errors.Join(ErrInvalidData, err)
 	}
 
-	proxy, err := u.proxyRepo.UpdateProxy(ctx, updatedProxy)
+	var proxy domain.Proxy
+	var err error
+	if keepPassword {
+		// Password is taken from the proxy locked by repository, so it is not lost on concurrent update
+		proxy, err = u.proxyRepo.ModifyProxy(ctx, updatedProxy.ID, updatedProxy.Version, func(current domain.Proxy) (domain.Proxy, bool, error) {
+			updatedProxy.Password = current.Password
+			return updatedProxy, true, nil
+		})
+	} else {
+		proxy, err = u.proxyRepo.UpdateProxy(ctx, updatedProxy, true)
+	}
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrAlreadyExists) || errors.Is(err, ErrVersionMismatch) {
 			return domain.Proxy{}, err
 		}
 		return domain.Proxy{}, errors.Join(ErrInRepo, err)
@@ -49,19 +101,58 @@ func (u *UseCase) UpdateProxy(ctx context.Context, updatedProxy domain.Proxy) (d
 	return proxy, nil
 }
 
-func (u *UseCase) DeleteProxy(ctx context.Context, proxyID int64) error {
-	if err := u.proxyRepo.DeleteProxy(ctx, proxyID); err != nil {
+// PatchProxy updates only given fields of proxy, its occupies are released only if
+// protocol, address or credentials are changed. If version is not 0, proxy is patched only if it has this version.
+func (u *UseCase) PatchProxy(ctx context.Context, proxyID int64, version int64, patch domain.ProxyPatch) (domain.Proxy, error) {
+	if proxyID <= 0 {
+		return domain.Proxy{}, errors.Join(ErrInvalidData, errors.New("ProxyID must be > 0"))
+	}
+
+	// Patch is applied to the proxy locked by repository, so concurrent update is not overwritten
+	patchedProxy, err := u.proxyRepo.ModifyProxy(ctx, proxyID, version, func(proxy domain.Proxy) (domain.Proxy, bool, error) {
+		patchedProxy := patch.Apply(proxy)
+		patchedProxy.ExpirationDate = patchedProxy.ExpirationDate.UTC()
+		patchedProxy.Tags = domain.NormalizeTags(patchedProxy.Tags)
+
+		if err := patchedProxy.Validate(); err != nil {
+			return domain.Proxy{}, false, errors.Join(ErrInvalidData, err)
+		}
+		return patchedProxy, !proxy.SameConnection(&patchedProxy), nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrAlreadyExists) || errors.Is(err, ErrVersionMismatch) ||
+			errors.Is(err, ErrInvalidData) {
+			return domain.Proxy{}, err
+		}
+		return domain.Proxy{}, errors.Join(ErrInRepo, err)
+	}
+	return patchedProxy, nil
+}
+
+// DeleteProxy deletes proxy, if version is not 0, proxy is deleted only if it has this version.
+func (u *UseCase) DeleteProxy(ctx context.Context, proxyID int64, version int64) error {
+	if err := u.proxyRepo.DeleteProxy(ctx, proxyID, version); err != nil {
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrVersionMismatch) {
+			return err
+		}
 		return errors.Join(ErrInRepo, err)
 	}
 	return nil
 }
 
-func (u *UseCase) GetProxyList(ctx context.Context, offset int64, limit int64) (domain.ProxyList, error) {
-	if offset < 0 || limit < 0 {
-		return domain.ProxyList{}, errors.Join(ErrInvalidData, errors.New("offset and limit must be non negative"))
+func (u *UseCase) GetProxyList(ctx context.Context, filter domain.ProxyFilter, sort domain.ProxySort, page domain.ProxyPage) (domain.ProxyList, error) {
+	filter.TagFilter.Normalize()
+	if err := filter.Validate(); err != nil {
+		return domain.ProxyList{}, errors.Join(ErrInvalidData, err)
+	}
+	if err := sort.Validate(); err != nil {
+		return domain.ProxyList{}, errors.Join(ErrInvalidData, err)
+	}
+	if err := page.Validate(sort); err != nil {
+		return domain.ProxyList{}, errors.Join(ErrInvalidData, err)
 	}
 
-	proxyList, err := u.proxyRepo.GetProxyList(ctx, offset, limit)
+	proxyList, err := u.proxyRepo.GetProxyList(ctx, filter, sort, page)
 	if err != nil {
 		return domain.ProxyList{}, errors.Join(ErrInRepo, err)
 	}
@@ -80,10 +171,29 @@ func (u *UseCase) GetProxy(ctx context.Context, proxyID int64) (domain.Proxy, er
 	return proxy, nil
 }
 
-func (u *UseCase) OccupyMostAvailableProxy(ctx context.Context) (domain.ProxyOccupy, error) {
-	proxyOccupy, err := u.proxyRepo.OccupyMostAvailableProxy(ctx)
+func (u *UseCase) OccupyMostAvailableProxy(ctx context.Context, opts domain.OccupyOptions) (domain.ProxyOccupy, error) {
+	opts.TargetDomain = domain.NormalizeDomain(opts.TargetDomain)
+	opts.TagFilter.Normalize()
+	if err := opts.Validate(); err != nil {
+		return domain.ProxyOccupy{}, errors.Join(ErrInvalidData, err)
+	}
+	opts.TTL = u.resolveOccupyTTL(opts.TTL)
+
+	picker, err := u.picker(opts.Strategy)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
+		return domain.ProxyOccupy{}, err
+	}
+
+	var proxyOccupy domain.ProxyOccupy
+	err = u.occupy(ctx, opts.Wait, func(ctx context.Context) error {
+		var err error
+		proxyOccupy, err = u.proxyRepo.OccupyMostAvailableProxy(ctx, opts, picker)
+		return err
+	}, func(ctx context.Context) error {
+		return u.proxyRepo.ReleaseProxy(ctx, proxyOccupy.Key, nil, u.scoring)
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrProxiesSaturated) {
 			return domain.ProxyOccupy{}, err
 		}
 		return domain.ProxyOccupy{}, errors.Join(ErrInRepo, err)
@@ -92,9 +202,258 @@ func (u *UseCase) OccupyMostAvailableProxy(ctx context.Context) (domain.ProxyOcc
 	return proxyOccupy, nil
 }
 
-func (u *UseCase) ReleaseProxy(ctx context.Context, key string) error {
-	if err := u.proxyRepo.ReleaseProxy(ctx, key); err != nil {
+func (u *UseCase) OccupyProxies(ctx context.Context, opts domain.BatchOccupyOptions) ([]domain.ProxyOccupy, error) {
+	opts.TargetDomain = domain.NormalizeDomain(opts.TargetDomain)
+	opts.TagFilter.Normalize()
+	if err := opts.Validate(); err != nil {
+		return nil, errors.Join(ErrInvalidData, err)
+	}
+	opts.TTL = u.resolveOccupyTTL(opts.TTL)
+
+	picker, err := u.picker(opts.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	var proxyOccupies []domain.ProxyOccupy
+	err = u.occupy(ctx, opts.Wait, func(ctx context.Context) error {
+		var err error
+		proxyOccupies, err = u.proxyRepo.OccupyProxies(ctx, opts, picker)
+		return err
+	}, func(ctx context.Context) error {
+		var errs []error
+		for _, proxyOccupy := range proxyOccupies {
+			errs = append(errs, u.proxyRepo.ReleaseProxy(ctx, proxyOccupy.Key, nil, u.scoring))
+		}
+		return errors.Join(errs...)
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrProxiesSaturated) {
+			return nil, err
+		}
+		return nil, errors.Join(ErrInRepo, err)
+	}
+
+	return proxyOccupies, nil
+}
+
+// occupy calls try once if wait is zero, otherwise queues it until it finds available proxy
+// or wait (capped by max wait) elapses. Try without wait is queued too, if some requests are waiting,
+// so it does not take proxy ahead of them. Release undoes successful try, if ctx is done while it runs.
+func (u *UseCase) occupy(ctx context.Context, wait time.Duration, try, release func(ctx context.Context) error) error {
+	if wait == 0 {
+		if u.waiters.empty() {
+			return try(ctx)
+		}
+		return u.waiters.wait(ctx, try, release, true)
+	}
+	if wait > u.maxOccupyWait {
+		wait = u.maxOccupyWait
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+	return u.waiters.wait(ctx, try, release, false)
+}
+
+// picker returns picker of strategy with given name, or of default strategy if name is empty.
+func (u *UseCase) picker(strategy string) (domain.ProxyPicker, error) {
+	if strategy == "" {
+		strategy = u.defaultStrategy
+	}
+
+	if err := ValidateStrategy(strategy); err != nil {
+		return nil, errors.Join(ErrInvalidData, err)
+	}
+	return u.strategies[strategy], nil
+}
+
+// resolveOccupyTTL returns default TTL instead of zero requested TTL and caps it by max TTL.
+func (u *UseCase) resolveOccupyTTL(ttl time.Duration) time.Duration {
+	if ttl == 0 {
+		ttl = u.occupyTTL
+	}
+	if ttl > u.maxOccupyTTL {
+		ttl = u.maxOccupyTTL
+	}
+	return ttl
+}
+
+// ReleaseProxy releases occupy with given key, optional outcome of proxy usage updates proxy score.
+func (u *UseCase) ReleaseProxy(ctx context.Context, key string, outcome *domain.ProxyOutcome) error {
+	if _, err := uuid.FromString(key); err != nil {
+		return errors.Join(ErrInvalidData, errors.New("key must be valid uuid"))
+	}
+
+	if outcome != nil {
+		if err := outcome.Validate(); err != nil {
+			return errors.Join(ErrInvalidData, err)
+		}
+	}
+
+	if err := u.proxyRepo.ReleaseProxy(ctx, key, outcome, u.scoring); err != nil {
 		return errors.Join(ErrInRepo, err)
 	}
 	return nil
 }
+
+func (u *UseCase) RenewProxyOccupy(ctx context.Context, key string) (domain.ProxyOccupy, error) {
+	if _, err := uuid.FromString(key); err != nil {
+		return domain.ProxyOccupy{}, errors.Join(ErrInvalidData, errors.New("key must be valid uuid"))
+	}
+
+	proxyOccupy, err := u.proxyRepo.RenewProxyOccupy(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return domain.ProxyOccupy{}, err
+		}
+		return domain.ProxyOccupy{}, errors.Join(ErrInRepo, err)
+	}
+
+	return proxyOccupy, nil
+}
+
+// BanProxy bans proxy of occupy with given key for target domain, zero cooldown means default one.
+func (u *UseCase) BanProxy(ctx context.Context, key string, targetDomain string, reason string, cooldown time.Duration) (domain.ProxyBan, error) {
+	if _, err := uuid.FromString(key); err != nil {
+		return domain.ProxyBan{}, errors.Join(ErrInvalidData, errors.New("key must be valid uuid"))
+	}
+
+	targetDomain = domain.NormalizeDomain(targetDomain)
+	if err := domain.ValidateDomain(targetDomain); err != nil {
+		return domain.ProxyBan{}, errors.Join(ErrInvalidData, err)
+	}
+
+	if cooldown < 0 {
+		return domain.ProxyBan{}, errors.Join(ErrInvalidData, errors.New("cooldown must be >= 0"))
+	}
+	if cooldown == 0 {
+		cooldown = u.banCooldown
+	}
+
+	ban, err := u.proxyRepo.BanProxy(ctx, key, targetDomain, reason, cooldown)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return domain.ProxyBan{}, err
+		}
+		return domain.ProxyBan{}, errors.Join(ErrInRepo, err)
+	}
+
+	return ban, nil
+}
+
+// ImportProxies parses proxy list and creates valid proxies in one transaction.
+// Unless opts.Partial is set, nothing is created if some line is invalid, in this case
+// result with per-line errors is returned along with ErrInvalidData.
+func (u *UseCase) ImportProxies(ctx context.Context, r io.Reader, opts domain.ImportOptions) (domain.ImportResult, error) {
+	opts.Tags = domain.NormalizeTags(opts.Tags)
+	if err := opts.Validate(); err != nil {
+		return domain.ImportResult{}, errors.Join(ErrInvalidData, err)
+	}
+
+	imported, importErrors, err := domain.ParseProxyList(r, opts)
+	if err != nil {
+		return domain.ImportResult{}, errors.Join(ErrInvalidData, err)
+	}
+
+	if !opts.SkipDuplicates {
+		imported, importErrors = rejectImportDuplicates(imported, importErrors)
+	}
+
+	result := domain.ImportResult{Errors: importErrors}
+	if len(importErrors) > 0 && !opts.Partial {
+		return result, errors.Join(ErrInvalidData, fmt.Errorf("%d lines are invalid", len(importErrors)))
+	}
+	if len(imported) == 0 {
+		return result, nil
+	}
+
+	proxies := make([]domain.Proxy, 0, len(imported))
+	for _, proxy := range imported {
+		proxies = append(proxies, proxy.Proxy)
+	}
+
+	ids, err := u.proxyRepo.ImportProxies(ctx, proxies, opts.SkipDuplicates)
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			return domain.ImportResult{}, err
+		}
+		return domain.ImportResult{}, errors.Join(ErrInRepo, err)
+	}
+
+	for _, id := range ids {
+		if id == 0 {
+			result.Skipped++
+		} else {
+			result.Imported++
+		}
+	}
+	return result, nil
+}
+
+// rejectImportDuplicates turns proxies, that duplicate previous lines of imported list, into errors.
+func rejectImportDuplicates(imported []domain.ImportedProxy, importErrors []domain.ImportError) ([]domain.ImportedProxy, []domain.ImportError) {
+	type proxyKey struct {
+		protocol, username, host string
+		port                     int64
+	}
+
+	firstLines := make(map[proxyKey]int, len(imported))
+	unique := imported[:0]
+	rejected := false
+	for _, proxy := range imported {
+		key := proxyKey{proxy.Proxy.Protocol, proxy.Proxy.Username, proxy.Proxy.Host, proxy.Proxy.Port}
+		if line, ok := firstLines[key]; ok {
+			importErrors = append(importErrors, domain.ImportError{Line: proxy.Line, Error: fmt.Sprintf("duplicates line %d", line)})
+			rejected = true
+			continue
+		}
+		firstLines[key] = proxy.Line
+		unique = append(unique, proxy)
+	}
+
+	if rejected {
+		sort.Slice(importErrors, func(i, j int) bool { return importErrors[i].Line < importErrors[j].Line })
+	}
+	return unique, importErrors
+}
+
+// DedupeProxies merges proxies with the same protocol, username, host and port, moving occupies,
+// bans and pools of duplicates to the kept proxy.
+func (u *UseCase) DedupeProxies(ctx context.Context) (domain.DedupeResult, error) {
+	result, err := u.proxyRepo.DedupeProxies(ctx)
+	if err != nil {
+		return domain.DedupeResult{}, errors.Join(ErrInRepo, err)
+	}
+	return result, nil
+}
+
+// ExportProxies writes proxies matching filter to w in given format, passwords are written only if includeCredentials is set.
+func (u *UseCase) ExportProxies(ctx context.Context, w io.Writer, filter domain.ProxyFilter, sort domain.ProxySort, format string, includeCredentials bool) error {
+	if err := domain.ValidateExportFormat(format); err != nil {
+		return errors.Join(ErrInvalidData, err)
+	}
+
+	// Cursor paging doesn't skip proxies, even if list is changed during export
+	var proxies []domain.Proxy
+	page := domain.ProxyPage{Limit: domain.ExportPageSize}
+	for {
+		proxyList, err := u.GetProxyList(ctx, filter, sort, page)
+		if err != nil {
+			return err
+		}
+
+		proxies = append(proxies, proxyList.Proxies...)
+		if proxyList.NextCursor == "" {
+			break
+		}
+		page.Cursor = proxyList.NextCursor
+	}
+
+	if !includeCredentials {
+		for i := range proxies {
+			proxies[i].HideCredentials()
+		}
+	}
+	return domain.WriteProxyList(w, proxies, format)
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"proxy_manager/pkg/logger"
+	"sync"
+	"time"
+)
+
+const (
+	// waitersFallbackWakeInterval is used to serve waiters, if some release notification was missed
+	waitersFallbackWakeInterval = time.Second * 5
+	listenRetryInterval         = time.Second * 5
+)
+
+// occupyWaiter is an occupy request, that waits for available proxy.
+type occupyWaiter struct {
+	// try occupies proxies and saves result, returns ErrNotFound or ErrProxiesSaturated if there is no available proxy
+	try func(ctx context.Context) error
+	// release undoes successful try, if waiter has gone while try was running
+	release func(ctx context.Context) error
+	result  chan error
+	once    bool // try is run only once in its turn, waiter does not wait for available proxy
+
+	elem      *list.Element
+	claimed   bool // try is running right now
+	abandoned bool // waiter has gone while try was running, so result must be released
+	lastErr   error
+}
+
+// occupyWaiters is a FIFO queue of occupy requests, waiting for available proxy.
+// Every time some proxy may become available, queued requests are tried one by one in order of arrival.
+type occupyWaiters struct {
+	mu    sync.Mutex
+	queue *list.List
+	wake  chan struct{}
+}
+
+func newOccupyWaiters() *occupyWaiters {
+	return &occupyWaiters{
+		queue: list.New(),
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// notify wakes up dispatcher, does not block.
+func (w *occupyWaiters) notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// empty reports whether no request is waiting for available proxy.
+func (w *occupyWaiters) empty() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.queue.Len() == 0
+}
+
+// wait enqueues try and blocks until it succeeds, fails with unexpected error or ctx is done.
+// In the last case the last ErrNotFound or ErrProxiesSaturated is returned and result of try,
+// that is running right now, is released by dispatcher.
+// If once is true, try is run only once in its turn after waiters queued before.
+func (w *occupyWaiters) wait(ctx context.Context, try, release func(ctx context.Context) error, once bool) error {
+	waiter := &occupyWaiter{
+		try:     try,
+		release: release,
+		result:  make(chan error, 1),
+		once:    once,
+		lastErr: ErrNotFound,
+	}
+
+	w.mu.Lock()
+	waiter.elem = w.queue.PushBack(waiter)
+	w.mu.Unlock()
+	w.notify()
+
+	select {
+	case err := <-waiter.result:
+		return err
+	case <-ctx.Done():
+	}
+
+	w.mu.Lock()
+	if waiter.elem == nil {
+		// Dispatcher has already sent result
+		w.mu.Unlock()
+		return <-waiter.result
+	}
+	if waiter.claimed {
+		// Dispatcher releases result of running try
+		waiter.abandoned = true
+		err := waiter.lastErr
+		w.mu.Unlock()
+		return err
+	}
+	w.queue.Remove(waiter.elem)
+	waiter.elem = nil
+	err := waiter.lastErr
+	w.mu.Unlock()
+
+	return err
+}
+
+// dispatch serves queued waiters every time it is notified, until ctx is done.
+func (w *occupyWaiters) dispatch(ctx context.Context, l logger.Interface) {
+	ticker := time.NewTicker(waitersFallbackWakeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.wake:
+		case <-ticker.C:
+		}
+
+		w.serve(ctx, l)
+	}
+}
+
+// serve tries every queued waiter once in order of arrival.
+func (w *occupyWaiters) serve(ctx context.Context, l logger.Interface) {
+	w.mu.Lock()
+	waiters := make([]*occupyWaiter, 0, w.queue.Len())
+	for e := w.queue.Front(); e != nil; e = e.Next() {
+		waiters = append(waiters, e.Value.(*occupyWaiter))
+	}
+	w.mu.Unlock()
+
+	for _, waiter := range waiters {
+		w.mu.Lock()
+		if waiter.elem == nil {
+			// Waiter has gone
+			w.mu.Unlock()
+			continue
+		}
+		waiter.claimed = true
+		w.mu.Unlock()
+
+		err := waiter.try(ctx)
+
+		w.mu.Lock()
+		waiter.claimed = false
+		noProxy := errors.Is(err, ErrNotFound) || errors.Is(err, ErrProxiesSaturated)
+		if noProxy && !waiter.abandoned && !waiter.once {
+			waiter.lastErr = err
+			w.mu.Unlock()
+			continue
+		}
+		w.queue.Remove(waiter.elem)
+		waiter.elem = nil
+		abandoned := waiter.abandoned
+		w.mu.Unlock()
+
+		if abandoned {
+			if err == nil {
+				if err := waiter.release(ctx); err != nil {
+					l.Error("UseCase - occupyWaiters - release - %s", err)
+				}
+			}
+			continue
+		}
+		waiter.result <- err
+	}
+}
+
+// StartOccupyWaitersDispatcher starts serving occupy requests, that wait for available proxy.
+// Waiters are woken up by proxy releases notifications from repository.
+func (u *UseCase) StartOccupyWaitersDispatcher(ctx context.Context, l logger.Interface) {
+	go u.waiters.dispatch(ctx, l)
+	go u.listenProxyReleases(ctx, l)
+}
+
+func (u *UseCase) listenProxyReleases(ctx context.Context, l logger.Interface) {
+	defer l.Info("Proxy releases listener exited!")
+	l.Info("Started proxy releases listener")
+
+	for {
+		err := u.proxyRepo.ListenProxyReleases(ctx, u.waiters.notify)
+		if ctx.Err() != nil {
+			return
+		}
+		l.Error("UseCase - listenProxyReleases - %s", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
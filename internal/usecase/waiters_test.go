@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"proxy_manager/pkg/logger"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOccupyWaiters_FIFO(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := newOccupyWaiters()
+	go w.dispatch(ctx, logger.NewTestLogger(t))
+
+	var mu sync.Mutex
+	available := 0
+	var served []int
+
+	results := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		i := i
+		go func() {
+			results <- w.wait(ctx, func(ctx context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				if available == 0 {
+					return ErrProxiesSaturated
+				}
+				available--
+				served = append(served, i)
+				return nil
+			}, nil, false)
+		}()
+
+		// Wait until waiter is queued, so the order of arrival is known
+		for {
+			w.mu.Lock()
+			queued := w.queue.Len()
+			w.mu.Unlock()
+			if queued == i+1 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		mu.Lock()
+		available++
+		mu.Unlock()
+		w.notify()
+
+		if err := <-results; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, waiter := range served {
+		if waiter != i {
+			t.Fatalf("waiters served in wrong order: %v", served)
+		}
+	}
+}
+
+func TestOccupyWaiters_Timeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := newOccupyWaiters()
+	go w.dispatch(ctx, logger.NewTestLogger(t))
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, time.Millisecond*50)
+	defer waitCancel()
+
+	err := w.wait(waitCtx, func(ctx context.Context) error {
+		return ErrProxiesSaturated
+	}, nil, false)
+	if !errors.Is(err, ErrProxiesSaturated) {
+		t.Fatalf("expected ErrProxiesSaturated, got %v", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.queue.Len() != 0 {
+		t.Fatalf("expected empty queue, got %d waiters", w.queue.Len())
+	}
+}
+
+func TestOccupyWaiters_OnceAfterQueued(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := newOccupyWaiters()
+
+	var mu sync.Mutex
+	available := 0
+	try := func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if available == 0 {
+			return ErrProxiesSaturated
+		}
+		available--
+		return nil
+	}
+
+	queued := make(chan error, 1)
+	go func() {
+		queued <- w.wait(ctx, try, nil, false)
+	}()
+	for w.empty() {
+		time.Sleep(time.Millisecond)
+	}
+
+	mu.Lock()
+	available++
+	mu.Unlock()
+	go w.dispatch(ctx, logger.NewTestLogger(t))
+
+	// Proxy is released for queued waiter, so request without wait must not take it
+	if err := w.wait(ctx, try, nil, true); !errors.Is(err, ErrProxiesSaturated) {
+		t.Fatalf("expected ErrProxiesSaturated, got %v", err)
+	}
+	if err := <-queued; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOccupyWaiters_AbandonedRelease(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := newOccupyWaiters()
+	go w.dispatch(ctx, logger.NewTestLogger(t))
+
+	waitCtx, waitCancel := context.WithCancel(ctx)
+	defer waitCancel()
+
+	claimed := make(chan struct{})
+	occupied := make(chan struct{})
+	released := make(chan struct{})
+	go func() {
+		<-claimed
+		waitCancel()
+	}()
+
+	err := w.wait(waitCtx, func(ctx context.Context) error {
+		close(claimed)
+		<-occupied
+		return nil
+	}, func(ctx context.Context) error {
+		close(released)
+		return nil
+	}, false)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	close(occupied)
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("occupy of abandoned waiter is not released")
+	}
+}
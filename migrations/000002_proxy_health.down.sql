@@ -0,0 +1,6 @@
+ALTER TABLE proxy
+    DROP COLUMN IF EXISTS healthy,
+    DROP COLUMN IF EXISTS check_failures,
+    DROP COLUMN IF EXISTS latency,
+    DROP COLUMN IF EXISTS check_error,
+    DROP COLUMN IF EXISTS checked_at;
\ No newline at end of file
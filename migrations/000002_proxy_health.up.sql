@@ -0,0 +1,6 @@
+ALTER TABLE proxy
+    ADD COLUMN IF NOT EXISTS healthy        BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS check_failures BIGINT  NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS latency        BIGINT  NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS check_error    TEXT    NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS checked_at     timestamp;
\ No newline at end of file
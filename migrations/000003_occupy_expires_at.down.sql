@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS proxy_occupy_expires_at_idx;
+
+ALTER TABLE proxy_occupy
+    DROP COLUMN IF EXISTS expires_at;
\ No newline at end of file
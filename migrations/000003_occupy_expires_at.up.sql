@@ -0,0 +1,12 @@
+ALTER TABLE proxy_occupy
+    ADD COLUMN IF NOT EXISTS expires_at timestamptz;
+
+UPDATE proxy_occupy
+SET expires_at = to_timestamp(create_timestamp) + INTERVAL '5 minutes'
+WHERE expires_at IS NULL;
+
+ALTER TABLE proxy_occupy
+    ALTER COLUMN expires_at SET DEFAULT now() + INTERVAL '5 minutes',
+    ALTER COLUMN expires_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS proxy_occupy_expires_at_idx ON proxy_occupy (expires_at);
\ No newline at end of file
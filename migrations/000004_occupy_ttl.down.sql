@@ -0,0 +1,2 @@
+ALTER TABLE proxy_occupy
+    DROP COLUMN IF EXISTS ttl;
\ No newline at end of file
@@ -0,0 +1,10 @@
+ALTER TABLE proxy_occupy
+    ADD COLUMN IF NOT EXISTS ttl DOUBLE PRECISION;
+
+UPDATE proxy_occupy
+SET ttl = GREATEST(EXTRACT(EPOCH FROM expires_at) - create_timestamp, 0)
+WHERE ttl IS NULL;
+
+ALTER TABLE proxy_occupy
+    ALTER COLUMN ttl SET DEFAULT 300,
+    ALTER COLUMN ttl SET NOT NULL;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE proxy
+    ADD COLUMN IF NOT EXISTS max_occupies BIGINT NOT NULL DEFAULT 0;
\ No newline at end of file
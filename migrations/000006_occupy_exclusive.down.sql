@@ -0,0 +1,2 @@
+ALTER TABLE proxy_occupy
+    DROP COLUMN IF EXISTS exclusive;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE proxy_occupy
+    ADD COLUMN IF NOT EXISTS exclusive BOOLEAN NOT NULL DEFAULT FALSE;
\ No newline at end of file
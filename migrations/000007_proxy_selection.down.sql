@@ -0,0 +1,3 @@
+ALTER TABLE proxy
+    DROP COLUMN IF EXISTS weight,
+    DROP COLUMN IF EXISTS last_occupied_at;
\ No newline at end of file
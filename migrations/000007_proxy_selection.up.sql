@@ -0,0 +1,3 @@
+ALTER TABLE proxy
+    ADD COLUMN IF NOT EXISTS weight           BIGINT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS last_occupied_at timestamptz;
\ No newline at end of file
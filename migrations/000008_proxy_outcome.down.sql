@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS proxy_outcome;
+
+ALTER TABLE proxy
+    DROP COLUMN IF EXISTS score,
+    DROP COLUMN IF EXISTS cooldown_until;
\ No newline at end of file
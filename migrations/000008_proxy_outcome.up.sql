@@ -0,0 +1,16 @@
+ALTER TABLE proxy
+    ADD COLUMN IF NOT EXISTS score          DOUBLE PRECISION NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS cooldown_until timestamptz;
+
+CREATE TABLE IF NOT EXISTS proxy_outcome
+(
+    outcome_id  BIGSERIAL PRIMARY KEY,
+    proxy_id    BIGINT REFERENCES proxy (proxy_id) ON DELETE CASCADE,
+    status      VARCHAR(32) NOT NULL,
+    reason      TEXT        NOT NULL DEFAULT '',
+    bytes       BIGINT      NOT NULL DEFAULT 0,
+    latency     BIGINT      NOT NULL DEFAULT 0,
+    reported_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS proxy_outcome_proxy_id_idx ON proxy_outcome (proxy_id);
\ No newline at end of file
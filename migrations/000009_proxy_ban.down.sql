@@ -0,0 +1 @@
+DROP TABLE IF EXISTS proxy_ban;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS proxy_ban
+(
+    proxy_id     BIGINT REFERENCES proxy (proxy_id) ON DELETE CASCADE,
+    domain       VARCHAR(255) NOT NULL,
+    reason       TEXT         NOT NULL DEFAULT '',
+    banned_until timestamptz  NOT NULL,
+    PRIMARY KEY (proxy_id, domain)
+);
+
+CREATE INDEX IF NOT EXISTS proxy_ban_banned_until_idx ON proxy_ban (banned_until);
\ No newline at end of file
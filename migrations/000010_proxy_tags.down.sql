@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS proxy_tags_idx;
+
+ALTER TABLE proxy
+    DROP COLUMN IF EXISTS tags;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE proxy
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS proxy_tags_idx ON proxy USING GIN (tags);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS proxy_pool;
+
+DROP TABLE IF EXISTS pool;
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS pool
+(
+    pool_id      BIGSERIAL PRIMARY KEY,
+    name         VARCHAR(64) NOT NULL UNIQUE,
+    occupy_ttl   BIGINT      NOT NULL DEFAULT 0,
+    strategy     VARCHAR(32) NOT NULL DEFAULT '',
+    max_occupies BIGINT      NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS proxy_pool
+(
+    pool_id  BIGINT REFERENCES pool (pool_id) ON DELETE CASCADE,
+    proxy_id BIGINT REFERENCES proxy (proxy_id) ON DELETE CASCADE,
+    PRIMARY KEY (pool_id, proxy_id)
+);
+
+CREATE INDEX IF NOT EXISTS proxy_pool_proxy_id_idx ON proxy_pool (proxy_id);
\ No newline at end of file
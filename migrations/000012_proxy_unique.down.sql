@@ -0,0 +1 @@
+DROP INDEX IF EXISTS proxy_unique_idx;
\ No newline at end of file
@@ -0,0 +1,51 @@
+-- Existing duplicates are merged into the proxy with the lowest ID, as by POST /proxies/dedupe,
+-- so unique index can be built: the latest expiration date and all tags, occupies, outcomes, pools and bans are kept
+CREATE TEMPORARY TABLE proxy_dup AS
+SELECT proxy_id, keep_id
+FROM (SELECT proxy_id, min(proxy_id) OVER (PARTITION BY protocol, username, host, port) AS keep_id FROM proxy) p
+WHERE proxy_id <> keep_id;
+
+UPDATE proxy
+SET expiration_date = GREATEST(proxy.expiration_date, m.expiration_date),
+    tags            = ARRAY(SELECT DISTINCT unnest(proxy.tags || m.tags) ORDER BY 1)
+FROM (SELECT proxy_dup.keep_id,
+             max(d.expiration_date)                                    AS expiration_date,
+             array_agg(DISTINCT t.tag) FILTER (WHERE t.tag IS NOT NULL) AS tags
+      FROM proxy_dup
+               JOIN proxy d ON d.proxy_id = proxy_dup.proxy_id
+               LEFT JOIN LATERAL unnest(d.tags) AS t(tag) ON TRUE
+      GROUP BY proxy_dup.keep_id) m
+WHERE proxy.proxy_id = m.keep_id;
+
+UPDATE proxy_occupy
+SET proxy_id = proxy_dup.keep_id
+FROM proxy_dup
+WHERE proxy_occupy.proxy_id = proxy_dup.proxy_id;
+
+UPDATE proxy_outcome
+SET proxy_id = proxy_dup.keep_id
+FROM proxy_dup
+WHERE proxy_outcome.proxy_id = proxy_dup.proxy_id;
+
+INSERT INTO proxy_pool(pool_id, proxy_id)
+SELECT proxy_pool.pool_id, proxy_dup.keep_id
+FROM proxy_pool
+         JOIN proxy_dup ON proxy_pool.proxy_id = proxy_dup.proxy_id
+ON CONFLICT DO NOTHING;
+
+INSERT INTO proxy_ban(proxy_id, domain, reason, banned_until)
+SELECT DISTINCT ON (proxy_dup.keep_id, proxy_ban.domain) proxy_dup.keep_id, proxy_ban.domain, proxy_ban.reason, proxy_ban.banned_until
+FROM proxy_ban
+         JOIN proxy_dup ON proxy_ban.proxy_id = proxy_dup.proxy_id
+ORDER BY proxy_dup.keep_id, proxy_ban.domain, proxy_ban.banned_until DESC
+ON CONFLICT (proxy_id, domain) DO UPDATE SET banned_until = GREATEST(proxy_ban.banned_until, excluded.banned_until),
+                                             reason       = excluded.reason
+WHERE excluded.banned_until > proxy_ban.banned_until;
+
+DELETE
+FROM proxy USING proxy_dup
+WHERE proxy.proxy_id = proxy_dup.proxy_id;
+
+DROP TABLE proxy_dup;
+
+CREATE UNIQUE INDEX IF NOT EXISTS proxy_unique_idx ON proxy (protocol, username, host, port);
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS api_key
+(
+    api_key_id BIGSERIAL PRIMARY KEY,
+    name       VARCHAR(64) NOT NULL,
+    role       VARCHAR(16) NOT NULL,
+    prefix     VARCHAR(16) NOT NULL,
+    key_hash   BYTEA       NOT NULL UNIQUE,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    revoked_at timestamptz
+);
\ No newline at end of file
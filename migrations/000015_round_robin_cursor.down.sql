@@ -0,0 +1 @@
+DROP TABLE IF EXISTS round_robin_cursor;
\ No newline at end of file
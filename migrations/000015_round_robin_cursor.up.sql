@@ -0,0 +1,6 @@
+-- Last proxy picked by round_robin strategy per pool, pool_id is 0 for occupies not from pool
+CREATE TABLE IF NOT EXISTS round_robin_cursor
+(
+    pool_id       BIGINT PRIMARY KEY,
+    last_proxy_id BIGINT NOT NULL
+);
\ No newline at end of file
@@ -0,0 +1,442 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: proxy/v1/proxy.proto
+
+package proxyv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ProxyService_CreateProxy_FullMethodName      = "/proxy_manager.proxy.v1.ProxyService/CreateProxy"
+	ProxyService_GetProxy_FullMethodName         = "/proxy_manager.proxy.v1.ProxyService/GetProxy"
+	ProxyService_UpdateProxy_FullMethodName      = "/proxy_manager.proxy.v1.ProxyService/UpdateProxy"
+	ProxyService_DeleteProxy_FullMethodName      = "/proxy_manager.proxy.v1.ProxyService/DeleteProxy"
+	ProxyService_ListProxies_FullMethodName      = "/proxy_manager.proxy.v1.ProxyService/ListProxies"
+	ProxyService_StreamProxies_FullMethodName    = "/proxy_manager.proxy.v1.ProxyService/StreamProxies"
+	ProxyService_OccupyProxy_FullMethodName      = "/proxy_manager.proxy.v1.ProxyService/OccupyProxy"
+	ProxyService_ReleaseProxy_FullMethodName     = "/proxy_manager.proxy.v1.ProxyService/ReleaseProxy"
+	ProxyService_RenewProxyOccupy_FullMethodName = "/proxy_manager.proxy.v1.ProxyService/RenewProxyOccupy"
+)
+
+// ProxyServiceClient is the client API for ProxyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProxyServiceClient interface {
+	CreateProxy(ctx context.Context, in *CreateProxyRequest, opts ...grpc.CallOption) (*Proxy, error)
+	GetProxy(ctx context.Context, in *GetProxyRequest, opts ...grpc.CallOption) (*Proxy, error)
+	// UpdateProxy replaces all fields of proxy, except password if it is not set, and releases its occupies.
+	UpdateProxy(ctx context.Context, in *UpdateProxyRequest, opts ...grpc.CallOption) (*Proxy, error)
+	DeleteProxy(ctx context.Context, in *DeleteProxyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListProxies(ctx context.Context, in *ListProxiesRequest, opts ...grpc.CallOption) (*ListProxiesResponse, error)
+	// StreamProxies sends every proxy matching filters in given order, paging fields of request are ignored.
+	StreamProxies(ctx context.Context, in *ListProxiesRequest, opts ...grpc.CallOption) (ProxyService_StreamProxiesClient, error)
+	// OccupyProxy occupies the most available proxy matching selector.
+	OccupyProxy(ctx context.Context, in *OccupyProxyRequest, opts ...grpc.CallOption) (*ProxyOccupy, error)
+	ReleaseProxy(ctx context.Context, in *ReleaseProxyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RenewProxyOccupy extends not yet expired occupy by its TTL.
+	RenewProxyOccupy(ctx context.Context, in *RenewProxyOccupyRequest, opts ...grpc.CallOption) (*ProxyOccupy, error)
+}
+
+type proxyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProxyServiceClient(cc grpc.ClientConnInterface) ProxyServiceClient {
+	return &proxyServiceClient{cc}
+}
+
+func (c *proxyServiceClient) CreateProxy(ctx context.Context, in *CreateProxyRequest, opts ...grpc.CallOption) (*Proxy, error) {
+	out := new(Proxy)
+	err := c.cc.Invoke(ctx, ProxyService_CreateProxy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyServiceClient) GetProxy(ctx context.Context, in *GetProxyRequest, opts ...grpc.CallOption) (*Proxy, error) {
+	out := new(Proxy)
+	err := c.cc.Invoke(ctx, ProxyService_GetProxy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyServiceClient) UpdateProxy(ctx context.Context, in *UpdateProxyRequest, opts ...grpc.CallOption) (*Proxy, error) {
+	out := new(Proxy)
+	err := c.cc.Invoke(ctx, ProxyService_UpdateProxy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyServiceClient) DeleteProxy(ctx context.Context, in *DeleteProxyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ProxyService_DeleteProxy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyServiceClient) ListProxies(ctx context.Context, in *ListProxiesRequest, opts ...grpc.CallOption) (*ListProxiesResponse, error) {
+	out := new(ListProxiesResponse)
+	err := c.cc.Invoke(ctx, ProxyService_ListProxies_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyServiceClient) StreamProxies(ctx context.Context, in *ListProxiesRequest, opts ...grpc.CallOption) (ProxyService_StreamProxiesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProxyService_ServiceDesc.Streams[0], ProxyService_StreamProxies_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &proxyServiceStreamProxiesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProxyService_StreamProxiesClient interface {
+	Recv() (*Proxy, error)
+	grpc.ClientStream
+}
+
+type proxyServiceStreamProxiesClient struct {
+	grpc.ClientStream
+}
+
+func (x *proxyServiceStreamProxiesClient) Recv() (*Proxy, error) {
+	m := new(Proxy)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *proxyServiceClient) OccupyProxy(ctx context.Context, in *OccupyProxyRequest, opts ...grpc.CallOption) (*ProxyOccupy, error) {
+	out := new(ProxyOccupy)
+	err := c.cc.Invoke(ctx, ProxyService_OccupyProxy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyServiceClient) ReleaseProxy(ctx context.Context, in *ReleaseProxyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ProxyService_ReleaseProxy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyServiceClient) RenewProxyOccupy(ctx context.Context, in *RenewProxyOccupyRequest, opts ...grpc.CallOption) (*ProxyOccupy, error) {
+	out := new(ProxyOccupy)
+	err := c.cc.Invoke(ctx, ProxyService_RenewProxyOccupy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProxyServiceServer is the server API for ProxyService service.
+// All implementations must embed UnimplementedProxyServiceServer
+// for forward compatibility
+type ProxyServiceServer interface {
+	CreateProxy(context.Context, *CreateProxyRequest) (*Proxy, error)
+	GetProxy(context.Context, *GetProxyRequest) (*Proxy, error)
+	// UpdateProxy replaces all fields of proxy, except password if it is not set, and releases its occupies.
+	UpdateProxy(context.Context, *UpdateProxyRequest) (*Proxy, error)
+	DeleteProxy(context.Context, *DeleteProxyRequest) (*emptypb.Empty, error)
+	ListProxies(context.Context, *ListProxiesRequest) (*ListProxiesResponse, error)
+	// StreamProxies sends every proxy matching filters in given order, paging fields of request are ignored.
+	StreamProxies(*ListProxiesRequest, ProxyService_StreamProxiesServer) error
+	// OccupyProxy occupies the most available proxy matching selector.
+	OccupyProxy(context.Context, *OccupyProxyRequest) (*ProxyOccupy, error)
+	ReleaseProxy(context.Context, *ReleaseProxyRequest) (*emptypb.Empty, error)
+	// RenewProxyOccupy extends not yet expired occupy by its TTL.
+	RenewProxyOccupy(context.Context, *RenewProxyOccupyRequest) (*ProxyOccupy, error)
+	mustEmbedUnimplementedProxyServiceServer()
+}
+
+// UnimplementedProxyServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedProxyServiceServer struct {
+}
+
+func (UnimplementedProxyServiceServer) CreateProxy(context.Context, *CreateProxyRequest) (*Proxy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProxy not implemented")
+}
+func (UnimplementedProxyServiceServer) GetProxy(context.Context, *GetProxyRequest) (*Proxy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProxy not implemented")
+}
+func (UnimplementedProxyServiceServer) UpdateProxy(context.Context, *UpdateProxyRequest) (*Proxy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProxy not implemented")
+}
+func (UnimplementedProxyServiceServer) DeleteProxy(context.Context, *DeleteProxyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProxy not implemented")
+}
+func (UnimplementedProxyServiceServer) ListProxies(context.Context, *ListProxiesRequest) (*ListProxiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProxies not implemented")
+}
+func (UnimplementedProxyServiceServer) StreamProxies(*ListProxiesRequest, ProxyService_StreamProxiesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamProxies not implemented")
+}
+func (UnimplementedProxyServiceServer) OccupyProxy(context.Context, *OccupyProxyRequest) (*ProxyOccupy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OccupyProxy not implemented")
+}
+func (UnimplementedProxyServiceServer) ReleaseProxy(context.Context, *ReleaseProxyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseProxy not implemented")
+}
+func (UnimplementedProxyServiceServer) RenewProxyOccupy(context.Context, *RenewProxyOccupyRequest) (*ProxyOccupy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewProxyOccupy not implemented")
+}
+func (UnimplementedProxyServiceServer) mustEmbedUnimplementedProxyServiceServer() {}
+
+// UnsafeProxyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProxyServiceServer will
+// result in compilation errors.
+type UnsafeProxyServiceServer interface {
+	mustEmbedUnimplementedProxyServiceServer()
+}
+
+func RegisterProxyServiceServer(s grpc.ServiceRegistrar, srv ProxyServiceServer) {
+	s.RegisterService(&ProxyService_ServiceDesc, srv)
+}
+
+func _ProxyService_CreateProxy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProxyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServiceServer).CreateProxy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProxyService_CreateProxy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServiceServer).CreateProxy(ctx, req.(*CreateProxyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProxyService_GetProxy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProxyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServiceServer).GetProxy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProxyService_GetProxy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServiceServer).GetProxy(ctx, req.(*GetProxyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProxyService_UpdateProxy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProxyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServiceServer).UpdateProxy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProxyService_UpdateProxy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServiceServer).UpdateProxy(ctx, req.(*UpdateProxyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProxyService_DeleteProxy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProxyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServiceServer).DeleteProxy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProxyService_DeleteProxy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServiceServer).DeleteProxy(ctx, req.(*DeleteProxyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProxyService_ListProxies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProxiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServiceServer).ListProxies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProxyService_ListProxies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServiceServer).ListProxies(ctx, req.(*ListProxiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProxyService_StreamProxies_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListProxiesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProxyServiceServer).StreamProxies(m, &proxyServiceStreamProxiesServer{stream})
+}
+
+type ProxyService_StreamProxiesServer interface {
+	Send(*Proxy) error
+	grpc.ServerStream
+}
+
+type proxyServiceStreamProxiesServer struct {
+	grpc.ServerStream
+}
+
+func (x *proxyServiceStreamProxiesServer) Send(m *Proxy) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ProxyService_OccupyProxy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OccupyProxyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServiceServer).OccupyProxy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProxyService_OccupyProxy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServiceServer).OccupyProxy(ctx, req.(*OccupyProxyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProxyService_ReleaseProxy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseProxyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServiceServer).ReleaseProxy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProxyService_ReleaseProxy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServiceServer).ReleaseProxy(ctx, req.(*ReleaseProxyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProxyService_RenewProxyOccupy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewProxyOccupyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServiceServer).RenewProxyOccupy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProxyService_RenewProxyOccupy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServiceServer).RenewProxyOccupy(ctx, req.(*RenewProxyOccupyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProxyService_ServiceDesc is the grpc.ServiceDesc for ProxyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProxyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proxy_manager.proxy.v1.ProxyService",
+	HandlerType: (*ProxyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateProxy",
+			Handler:    _ProxyService_CreateProxy_Handler,
+		},
+		{
+			MethodName: "GetProxy",
+			Handler:    _ProxyService_GetProxy_Handler,
+		},
+		{
+			MethodName: "UpdateProxy",
+			Handler:    _ProxyService_UpdateProxy_Handler,
+		},
+		{
+			MethodName: "DeleteProxy",
+			Handler:    _ProxyService_DeleteProxy_Handler,
+		},
+		{
+			MethodName: "ListProxies",
+			Handler:    _ProxyService_ListProxies_Handler,
+		},
+		{
+			MethodName: "OccupyProxy",
+			Handler:    _ProxyService_OccupyProxy_Handler,
+		},
+		{
+			MethodName: "ReleaseProxy",
+			Handler:    _ProxyService_ReleaseProxy_Handler,
+		},
+		{
+			MethodName: "RenewProxyOccupy",
+			Handler:    _ProxyService_RenewProxyOccupy_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamProxies",
+			Handler:       _ProxyService_StreamProxies_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proxy/v1/proxy.proto",
+}
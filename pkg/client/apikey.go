@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Roles of API keys.
+const (
+	RoleAdmin    = "admin"     // full access
+	RoleConsumer = "consumer"  // occupy, renew, ban and release proxies
+	RoleReadOnly = "read_only" // read proxies and pools
+)
+
+type APIKey struct {
+	ID        int64      `json:"api_key_id"`
+	Name      string     `json:"name"`
+	Role      string     `json:"role"`
+	Prefix    string     `json:"prefix"` // beginning of token to recognize it
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+// CreateAPIKey creates API key with given role and returns it with its token, which can't be got again.
+func (c *Client) CreateAPIKey(ctx context.Context, name string, role string) (APIKey, string, error) {
+	r := request{method: http.MethodPost, path: "/api-keys"}
+	err := r.jsonBody(struct {
+		Name string `json:"name"`
+		Role string `json:"role"`
+	}{Name: name, Role: role})
+	if err != nil {
+		return APIKey{}, "", err
+	}
+
+	var resp struct {
+		APIKey APIKey `json:"api_key"`
+		Token  string `json:"token"`
+	}
+	err = c.doJSON(ctx, r, &resp)
+	return resp.APIKey, resp.Token, err
+}
+
+// ListAPIKeys returns all API keys including revoked ones.
+func (c *Client) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	var keys []APIKey
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/api-keys"}, &keys)
+	return keys, err
+}
+
+// RevokeAPIKey revokes API key, its token isn't accepted anymore.
+func (c *Client) RevokeAPIKey(ctx context.Context, keyID int64) error {
+	return c.doJSON(ctx, request{method: http.MethodDelete, path: "/api-keys/" + strconv.FormatInt(keyID, 10)}, nil)
+}
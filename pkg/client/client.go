@@ -0,0 +1,163 @@
+// Package client is a Go client of proxy manager HTTP API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// apiPrefix is a path of API version used by client.
+const apiPrefix = "/api/v1"
+
+// Client calls proxy manager HTTP API, it is safe for concurrent use.
+type Client struct {
+	baseURL            *url.URL
+	httpClient         *http.Client
+	token              string
+	includeCredentials bool
+}
+
+// Option configures Client.
+type Option func(*Client)
+
+// WithHTTPClient sets HTTP client used for API calls, http.DefaultClient is used by default.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken sets token sent in Authorization header as bearer token.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithCredentials makes client request proxy passwords, which are hidden by default everywhere except occupies.
+// Token of API key with admin role is required, otherwise requests fail with ErrForbidden.
+func WithCredentials() Option {
+	return func(c *Client) {
+		c.includeCredentials = true
+	}
+}
+
+// New creates Client of service at baseURL, e.g. http://localhost:9000.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, errors.New("base url must be absolute http or https url")
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// BaseURL returns URL of service, that client calls.
+func (c *Client) BaseURL() string {
+	return c.baseURL.String()
+}
+
+// request is a single API call.
+type request struct {
+	method      string
+	path        string // relative to API prefix
+	query       url.Values
+	header      http.Header
+	body        io.Reader
+	contentType string
+	credentials bool // response has proxies with passwords hidden by default
+}
+
+// jsonBody marshals v as request body.
+func (r *request) jsonBody(v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	r.body = bytes.NewReader(body)
+	r.contentType = "application/json"
+	return nil
+}
+
+// do sends request and returns response with 2xx status, other statuses are returned as *Error.
+// Caller must close body of returned response.
+func (c *Client) do(ctx context.Context, r request) (*http.Response, error) {
+	u := *c.baseURL
+	u.Path += apiPrefix + r.path
+	query := r.query
+	if r.credentials && c.includeCredentials {
+		query = url.Values{"include_credentials": {"true"}}
+		for name, values := range r.query {
+			query[name] = values
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, r.method, u.String(), r.body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range r.header {
+		req.Header[name] = values
+	}
+	if r.contentType != "" {
+		req.Header.Set("Content-Type", r.contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		return nil, newError(resp)
+	}
+	return resp, nil
+}
+
+// doJSON sends request and decodes JSON response into out, if it is not nil.
+func (c *Client) doJSON(ctx context.Context, r request, out interface{}) error {
+	resp, err := c.do(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// ifMatchHeader returns If-Match header for given proxy version, nil if version is 0.
+func ifMatchHeader(version int64) http.Header {
+	if version == 0 {
+		return nil
+	}
+	return http.Header{"If-Match": []string{fmt.Sprintf(`"%d"`, version)}}
+}
@@ -0,0 +1,353 @@
+package client_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"proxy_manager/internal/controller/http/v1"
+	"proxy_manager/internal/domain"
+	"proxy_manager/internal/usecase"
+	"proxy_manager/pkg/client"
+	"proxy_manager/pkg/logger"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid/v5"
+)
+
+// memoryProxyRepo keeps proxies in memory, it implements only methods used by tests.
+type memoryProxyRepo struct {
+	domain.ProxyRepository
+
+	mu       sync.Mutex
+	lastID   int64
+	proxies  map[int64]domain.Proxy
+	occupies map[string]int64
+}
+
+func newMemoryProxyRepo() *memoryProxyRepo {
+	return &memoryProxyRepo{proxies: map[int64]domain.Proxy{}, occupies: map[string]int64{}}
+}
+
+func (r *memoryProxyRepo) CreateProxy(_ context.Context, proxy domain.Proxy) (domain.Proxy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, p := range r.proxies {
+		if p.Protocol == proxy.Protocol && p.Username == proxy.Username && p.Host == proxy.Host && p.Port == proxy.Port {
+			return domain.Proxy{}, &usecase.DuplicateProxyError{ProxyID: p.ID}
+		}
+	}
+
+	r.lastID++
+	proxy.ID, proxy.Version, proxy.Enabled, proxy.Healthy = r.lastID, 1, true, true
+	r.proxies[proxy.ID] = proxy
+	return proxy, nil
+}
+
+func (r *memoryProxyRepo) GetProxy(_ context.Context, proxyID int64) (domain.Proxy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	proxy, ok := r.proxies[proxyID]
+	if !ok {
+		return domain.Proxy{}, usecase.ErrNotFound
+	}
+	return proxy, nil
+}
+
+func (r *memoryProxyRepo) UpdateProxy(_ context.Context, proxy domain.Proxy, _ bool) (domain.Proxy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.proxies[proxy.ID]
+	if !ok {
+		return domain.Proxy{}, usecase.ErrNotFound
+	}
+	if proxy.Version != 0 && proxy.Version != current.Version {
+		return domain.Proxy{}, usecase.ErrVersionMismatch
+	}
+
+	proxy.Version, proxy.Enabled, proxy.Healthy = current.Version+1, true, true
+	r.proxies[proxy.ID] = proxy
+	return proxy, nil
+}
+
+func (r *memoryProxyRepo) ModifyProxy(_ context.Context, proxyID int64, version int64, modify domain.ProxyModifier) (domain.Proxy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.proxies[proxyID]
+	if !ok {
+		return domain.Proxy{}, usecase.ErrNotFound
+	}
+	if version != 0 && version != current.Version {
+		return domain.Proxy{}, usecase.ErrVersionMismatch
+	}
+
+	proxy, _, err := modify(current)
+	if err != nil {
+		return domain.Proxy{}, err
+	}
+	proxy.ID, proxy.Version, proxy.Enabled, proxy.Healthy = proxyID, current.Version+1, true, true
+	r.proxies[proxyID] = proxy
+	return proxy, nil
+}
+
+func (r *memoryProxyRepo) GetProxyList(_ context.Context, _ domain.ProxyFilter, _ domain.ProxySort, page domain.ProxyPage) (domain.ProxyList, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := domain.ProxyList{Offset: page.Offset}
+	for id := int64(1); id <= r.lastID; id++ {
+		if proxy, ok := r.proxies[id]; ok {
+			list.Proxies = append(list.Proxies, proxy)
+		}
+	}
+	total := int64(len(list.Proxies))
+	list.Total = &total
+	return list, nil
+}
+
+func (r *memoryProxyRepo) OccupyMostAvailableProxy(_ context.Context, opts domain.OccupyOptions, _ domain.ProxyPicker) (domain.ProxyOccupy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id := int64(1); id <= r.lastID; id++ {
+		proxy, ok := r.proxies[id]
+		if !ok || opts.Protocol != "" && proxy.Protocol != opts.Protocol {
+			continue
+		}
+
+		key := uuid.Must(uuid.NewV4()).String()
+		r.occupies[key] = proxy.ID
+		return domain.ProxyOccupy{Proxy: proxy, Key: key, ExpiresAt: time.Now().Add(opts.TTL)}, nil
+	}
+	return domain.ProxyOccupy{}, usecase.ErrNotFound
+}
+
+func (r *memoryProxyRepo) ReleaseProxy(_ context.Context, key string, _ *domain.ProxyOutcome, _ domain.ProxyScoring) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.occupies, key)
+	return nil
+}
+
+func (r *memoryProxyRepo) occupiesCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.occupies)
+}
+
+func newTestClient(t *testing.T) (*client.Client, *memoryProxyRepo) {
+	gin.SetMode(gin.TestMode)
+
+	repo := newMemoryProxyRepo()
+	u := usecase.New(repo, nil, time.Minute, time.Hour, time.Minute, usecase.StrategyLeastOccupied, domain.ProxyScoring{Decay: 0.5}, time.Hour)
+
+	auth := usecase.NewAuth(nil, true, "admin-token")
+
+	handler := gin.New()
+	v1.NewRouter(handler, u, auth, logger.NewTestLogger(t), false)
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := client.New(server.URL, client.WithToken("admin-token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, repo
+}
+
+func TestClient_Proxies(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	password := "qwerty"
+	data := client.ProxyData{
+		Protocol:       client.ProtocolHTTP,
+		Host:           "127.0.0.1",
+		Port:           8080,
+		Username:       "login123",
+		Password:       &password,
+		ExpirationDate: time.Now().Add(time.Hour),
+		Tags:           []string{"provider=acme"},
+	}
+	proxy, err := c.CreateProxy(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proxy.ID == 0 || proxy.Host != data.Host || proxy.Version != 1 || len(proxy.Tags) != 1 || proxy.Password != "" {
+		t.Fatalf("unexpected created proxy %+v", proxy)
+	}
+
+	_, err = c.CreateProxy(ctx, data)
+	var apiErr *client.Error
+	if !errors.Is(err, client.ErrAlreadyExists) || !errors.As(err, &apiErr) || apiErr.ProxyID != proxy.ID {
+		t.Fatalf("expected duplicate of proxy %d, got %v", proxy.ID, err)
+	}
+
+	port := int64(3128)
+	patched, err := c.PatchProxy(ctx, proxy.ID, proxy.Version, client.ProxyPatch{Port: &port})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patched.Port != port || patched.Username != data.Username || patched.Version != 2 {
+		t.Fatalf("unexpected patched proxy %+v", patched)
+	}
+
+	if _, err := c.UpdateProxy(ctx, proxy.ID, proxy.Version, data); !errors.Is(err, client.ErrVersionMismatch) {
+		t.Fatalf("expected version mismatch, got %v", err)
+	}
+
+	if _, err := c.GetProxy(ctx, 100); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	list, err := c.ListProxies(ctx, client.ListOptions{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Proxies) != 1 || list.Total == nil || *list.Total != 1 || list.Proxies[0].Port != port {
+		t.Fatalf("unexpected proxy list %+v", list)
+	}
+
+	if _, err := c.ListProxies(ctx, client.ListOptions{Limit: -1}); !errors.Is(err, client.ErrInvalidData) {
+		t.Fatalf("expected invalid data, got %v", err)
+	}
+
+	withCredentials, err := client.New(c.BaseURL(), client.WithToken("admin-token"), client.WithCredentials())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := withCredentials.GetProxy(ctx, proxy.ID); err != nil || got.Password != password {
+		t.Fatalf("expected proxy with password, got %+v, %v", got, err)
+	}
+
+	anonymous, err := client.New(c.BaseURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := anonymous.GetProxy(ctx, proxy.ID); !errors.Is(err, client.ErrUnauthorized) {
+		t.Fatalf("expected unauthorized, got %v", err)
+	}
+}
+
+func TestClient_UpdateProxyPassword(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	withCredentials, err := client.New(c.BaseURL(), client.WithToken("admin-token"), client.WithCredentials())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	password := "qwerty"
+	data := client.ProxyData{
+		Protocol:       client.ProtocolHTTP,
+		Host:           "127.0.0.1",
+		Port:           8080,
+		Username:       "login123",
+		Password:       &password,
+		ExpirationDate: time.Now().Add(time.Hour),
+	}
+	proxy, err := c.CreateProxy(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Proxy read without credentials is written back with its password kept
+	data.Password = nil
+	if _, err := c.UpdateProxy(ctx, proxy.ID, 0, data); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := withCredentials.GetProxy(ctx, proxy.ID); err != nil || got.Password != password {
+		t.Fatalf("expected kept password, got %+v, %v", got, err)
+	}
+
+	empty := ""
+	data.Password = &empty
+	if _, err := c.UpdateProxy(ctx, proxy.ID, 0, data); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := withCredentials.GetProxy(ctx, proxy.ID); err != nil || got.Password != "" {
+		t.Fatalf("expected cleared password, got %+v, %v", got, err)
+	}
+}
+
+func TestClient_Lease(t *testing.T) {
+	c, repo := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := c.Lease(ctx, client.OccupyRequest{}); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	// Upstream proxy answers itself instead of forwarding requests
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := "Basic " + base64.StdEncoding.EncodeToString([]byte("login123:qwerty"))
+		if r.Header.Get("Proxy-Authorization") != auth {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		io.WriteString(w, "proxied "+r.URL.String())
+	}))
+	defer upstream.Close()
+
+	upstreamURL, _ := url.Parse(upstream.URL)
+	port, _ := strconv.ParseInt(upstreamURL.Port(), 10, 64)
+	password := "qwerty"
+	_, err := c.CreateProxy(ctx, client.ProxyData{
+		Protocol:       client.ProtocolHTTP,
+		Host:           upstreamURL.Hostname(),
+		Port:           port,
+		Username:       "login123",
+		Password:       &password,
+		ExpirationDate: time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lease, err := c.Lease(ctx, client.OccupyRequest{Protocol: client.ProtocolHTTP, TTL: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.occupiesCount() != 1 {
+		t.Fatalf("expected 1 occupy, got %d", repo.occupiesCount())
+	}
+
+	resp, err := (&http.Client{Transport: lease.Transport()}).Get("http://example.test/page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.HasPrefix(string(body), "proxied http://example.test/page") {
+		t.Fatalf("request wasn't sent through proxy, got %d %q", resp.StatusCode, body)
+	}
+
+	if _, err := lease.Dialer(); err == nil {
+		t.Fatal("expected dialer error for http proxy")
+	}
+
+	if err := lease.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := lease.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if repo.occupiesCount() != 0 {
+		t.Fatalf("expected released occupy, got %d", repo.occupiesCount())
+	}
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Errors matched by *Error with errors.Is according to response status.
+var (
+	ErrInvalidData      = errors.New("invalid data")      // 400
+	ErrUnauthorized     = errors.New("unauthorized")      // 401
+	ErrForbidden        = errors.New("forbidden")         // 403
+	ErrNotFound         = errors.New("not found")         // 404
+	ErrAlreadyExists    = errors.New("already exists")    // 409
+	ErrVersionMismatch  = errors.New("version mismatch")  // 412
+	ErrProxiesSaturated = errors.New("proxies saturated") // 503
+)
+
+var statusErrors = map[int]error{
+	http.StatusBadRequest:         ErrInvalidData,
+	http.StatusUnauthorized:       ErrUnauthorized,
+	http.StatusForbidden:          ErrForbidden,
+	http.StatusNotFound:           ErrNotFound,
+	http.StatusConflict:           ErrAlreadyExists,
+	http.StatusPreconditionFailed: ErrVersionMismatch,
+	http.StatusServiceUnavailable: ErrProxiesSaturated,
+}
+
+// maxErrorBodySize limits size of error response read by client.
+const maxErrorBodySize = 1 << 20
+
+// Error is an error response of API.
+type Error struct {
+	StatusCode int
+	Message    string
+	ProxyID    int64 // ID of existing proxy, if proxy already exists
+
+	body []byte
+}
+
+func newError(resp *http.Response) *Error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+	var errResp struct {
+		Error   string `json:"error"`
+		ProxyID int64  `json:"proxy_id"`
+	}
+	_ = json.Unmarshal(body, &errResp)
+
+	message := errResp.Error
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	return &Error{StatusCode: resp.StatusCode, Message: message, ProxyID: errResp.ProxyID, body: body}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("proxy manager: %d %s", e.StatusCode, e.Message)
+}
+
+// Is reports whether target is one of Err* matching status of response.
+func (e *Error) Is(target error) bool {
+	return statusErrors[e.StatusCode] == target
+}
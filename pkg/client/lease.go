@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// releaseTimeout limits release of proxy on Lease.Close.
+const releaseTimeout = 10 * time.Second
+
+// Lease is an occupied proxy, that is released on Close.
+type Lease struct {
+	ProxyOccupy
+
+	client    *Client
+	mu        sync.Mutex
+	transport *http.Transport
+	released  bool
+}
+
+// Lease occupies proxy matching request, caller must Close returned lease to release proxy.
+func (c *Client) Lease(ctx context.Context, req OccupyRequest) (*Lease, error) {
+	occupy, err := c.OccupyProxy(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &Lease{ProxyOccupy: occupy, client: c}, nil
+}
+
+// LeasePoolProxy is like Lease, but occupies proxy of pool.
+func (c *Client) LeasePoolProxy(ctx context.Context, pool string, req OccupyRequest) (*Lease, error) {
+	occupy, err := c.OccupyPoolProxy(ctx, pool, req)
+	if err != nil {
+		return nil, err
+	}
+	return &Lease{ProxyOccupy: occupy, client: c}, nil
+}
+
+// Transport returns HTTP transport, that sends requests through leased proxy.
+// It is based on http.DefaultTransport and its idle connections are closed on Close.
+func (l *Lease) Transport() *http.Transport {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.transport == nil {
+		l.transport = http.DefaultTransport.(*http.Transport).Clone()
+		l.transport.Proxy = http.ProxyURL(l.Proxy.URL())
+	}
+	return l.transport
+}
+
+// Dialer returns dialer, that connects through leased proxy, only socks5 proxies support it.
+func (l *Lease) Dialer() (proxy.ContextDialer, error) {
+	if l.Proxy.Protocol != ProtocolSOCKS5 {
+		return nil, errors.New("dialer is supported only by socks5 proxies")
+	}
+
+	var auth *proxy.Auth
+	if l.Proxy.Username != "" || l.Proxy.Password != "" {
+		auth = &proxy.Auth{User: l.Proxy.Username, Password: l.Proxy.Password}
+	}
+	dialer, err := proxy.SOCKS5("tcp", l.Proxy.URL().Host, auth, &net.Dialer{})
+	if err != nil {
+		return nil, err
+	}
+	return dialer.(proxy.ContextDialer), nil
+}
+
+// Renew extends lease by its TTL.
+func (l *Lease) Renew(ctx context.Context) error {
+	occupy, err := l.client.RenewOccupy(ctx, l.Key)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.ExpiresAt = occupy.ExpiresAt
+	l.mu.Unlock()
+	return nil
+}
+
+// Release releases proxy reporting optional outcome of its usage, subsequent calls do nothing.
+func (l *Lease) Release(ctx context.Context, outcome *Outcome) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.released {
+		return nil
+	}
+	if l.transport != nil {
+		l.transport.CloseIdleConnections()
+	}
+
+	if err := l.client.ReleaseProxy(ctx, l.Key, outcome); err != nil {
+		return err
+	}
+	l.released = true
+	return nil
+}
+
+// Close releases proxy without outcome.
+func (l *Lease) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	return l.Release(ctx, nil)
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+func poolPath(name string) string {
+	return "/pools/" + url.PathEscape(name)
+}
+
+// poolSettingsBody is a JSON body of pool update.
+type poolSettingsBody struct {
+	OccupyTTL   int64  `json:"occupy_ttl"`
+	Strategy    string `json:"strategy"`
+	MaxOccupies int64  `json:"max_occupies"`
+}
+
+// CreatePool creates pool, ErrAlreadyExists is returned if pool with such name exists.
+func (c *Client) CreatePool(ctx context.Context, pool Pool) (Pool, error) {
+	r := request{method: http.MethodPost, path: "/pools"}
+	if err := r.jsonBody(pool); err != nil {
+		return Pool{}, err
+	}
+
+	var created Pool
+	err := c.doJSON(ctx, r, &created)
+	return created, err
+}
+
+// ListPools returns all pools ordered by name.
+func (c *Client) ListPools(ctx context.Context) ([]Pool, error) {
+	var pools []Pool
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/pools"}, &pools)
+	return pools, err
+}
+
+func (c *Client) GetPool(ctx context.Context, name string) (Pool, error) {
+	var pool Pool
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: poolPath(name)}, &pool)
+	return pool, err
+}
+
+// UpdatePool updates settings of pool with pool.Name.
+func (c *Client) UpdatePool(ctx context.Context, pool Pool) (Pool, error) {
+	r := request{method: http.MethodPut, path: poolPath(pool.Name)}
+	err := r.jsonBody(poolSettingsBody{OccupyTTL: pool.OccupyTTL, Strategy: pool.Strategy, MaxOccupies: pool.MaxOccupies})
+	if err != nil {
+		return Pool{}, err
+	}
+
+	var updated Pool
+	err = c.doJSON(ctx, r, &updated)
+	return updated, err
+}
+
+func (c *Client) DeletePool(ctx context.Context, name string) error {
+	return c.doJSON(ctx, request{method: http.MethodDelete, path: poolPath(name)}, nil)
+}
+
+// AddPoolProxies adds proxies to pool, nothing is added if some of proxies don't exist.
+func (c *Client) AddPoolProxies(ctx context.Context, name string, proxyIDs []int64) error {
+	return c.poolProxies(ctx, poolPath(name)+"/proxies", proxyIDs)
+}
+
+func (c *Client) RemovePoolProxies(ctx context.Context, name string, proxyIDs []int64) error {
+	return c.poolProxies(ctx, poolPath(name)+"/proxies/remove", proxyIDs)
+}
+
+func (c *Client) poolProxies(ctx context.Context, path string, proxyIDs []int64) error {
+	r := request{method: http.MethodPost, path: path}
+	err := r.jsonBody(struct {
+		ProxyIDs []int64 `json:"proxy_ids"`
+	}{ProxyIDs: proxyIDs})
+	if err != nil {
+		return err
+	}
+	return c.doJSON(ctx, r, nil)
+}
+
+// OccupyPoolProxy occupies proxy of pool like OccupyProxy, but with TTL and strategy of pool by default.
+func (c *Client) OccupyPoolProxy(ctx context.Context, name string, req OccupyRequest) (ProxyOccupy, error) {
+	r := request{method: http.MethodPost, path: poolPath(name) + "/occupy"}
+	if err := r.jsonBody(req.body()); err != nil {
+		return ProxyOccupy{}, err
+	}
+
+	var occupy ProxyOccupy
+	err := c.doJSON(ctx, r, &occupy)
+	return occupy, err
+}
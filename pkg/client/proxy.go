@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+func proxyPath(proxyID int64) string {
+	return "/proxies/" + strconv.FormatInt(proxyID, 10)
+}
+
+// CreateProxy creates proxy, if the same proxy already exists, *Error with its ProxyID is returned.
+func (c *Client) CreateProxy(ctx context.Context, data ProxyData) (Proxy, error) {
+	r := request{method: http.MethodPost, path: "/proxies", credentials: true}
+	if err := r.jsonBody(data); err != nil {
+		return Proxy{}, err
+	}
+
+	var proxy Proxy
+	err := c.doJSON(ctx, r, &proxy)
+	return proxy, err
+}
+
+func (c *Client) GetProxy(ctx context.Context, proxyID int64) (Proxy, error) {
+	var proxy Proxy
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: proxyPath(proxyID), credentials: true}, &proxy)
+	return proxy, err
+}
+
+// UpdateProxy replaces proxy data and releases all its occupies, password is kept if data.Password is nil.
+// If version is not 0, proxy is updated only if it still has this version, otherwise ErrVersionMismatch is returned.
+func (c *Client) UpdateProxy(ctx context.Context, proxyID int64, version int64, data ProxyData) (Proxy, error) {
+	r := request{method: http.MethodPut, path: proxyPath(proxyID), header: ifMatchHeader(version), credentials: true}
+	if err := r.jsonBody(data); err != nil {
+		return Proxy{}, err
+	}
+
+	var proxy Proxy
+	err := c.doJSON(ctx, r, &proxy)
+	return proxy, err
+}
+
+// PatchProxy updates only given fields of proxy, its occupies are released only if
+// protocol, address or credentials are changed. Version is checked as in UpdateProxy.
+func (c *Client) PatchProxy(ctx context.Context, proxyID int64, version int64, patch ProxyPatch) (Proxy, error) {
+	r := request{method: http.MethodPatch, path: proxyPath(proxyID), header: ifMatchHeader(version), credentials: true}
+	if err := r.jsonBody(patch); err != nil {
+		return Proxy{}, err
+	}
+	r.contentType = "application/merge-patch+json"
+
+	var proxy Proxy
+	err := c.doJSON(ctx, r, &proxy)
+	return proxy, err
+}
+
+// DeleteProxy deletes proxy, deleting of not existing proxy is not an error unless version is set,
+// then ErrNotFound is returned. Version is checked as in UpdateProxy.
+func (c *Client) DeleteProxy(ctx context.Context, proxyID int64, version int64) error {
+	r := request{method: http.MethodDelete, path: proxyPath(proxyID), header: ifMatchHeader(version)}
+	return c.doJSON(ctx, r, nil)
+}
+
+// ListProxies returns page of proxy list.
+func (c *Client) ListProxies(ctx context.Context, opts ListOptions) (ProxyList, error) {
+	var list ProxyList
+	err := c.doJSON(ctx, request{method: http.MethodGet, path: "/proxies", query: opts.values(), credentials: true}, &list)
+	return list, err
+}
+
+// ImportProxies creates proxies from list read from r in one transaction.
+// If some lines are invalid, *Error matching ErrInvalidData is returned together with per-line errors in result.
+func (c *Client) ImportProxies(ctx context.Context, r io.Reader, opts ImportOptions) (ImportResult, error) {
+	var result ImportResult
+	err := c.doJSON(ctx, request{
+		method:      http.MethodPost,
+		path:        "/proxies/import",
+		query:       opts.values(),
+		body:        r,
+		contentType: "text/plain",
+	}, &result)
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+		_ = json.Unmarshal(apiErr.body, &result)
+	}
+	return result, err
+}
+
+// DedupeProxies merges proxies with the same protocol, username, host and port into the one with the lowest ID.
+func (c *Client) DedupeProxies(ctx context.Context) (DedupeResult, error) {
+	var result DedupeResult
+	err := c.doJSON(ctx, request{method: http.MethodPost, path: "/proxies/dedupe"}, &result)
+	return result, err
+}
+
+// ExportProxies writes all proxies matching filter to w in given format, one of ExportFormat* constants.
+func (c *Client) ExportProxies(ctx context.Context, w io.Writer, format string, filter ProxyFilter) error {
+	q := filter.values()
+	q.Set("format", format)
+
+	resp, err := c.do(ctx, request{method: http.MethodGet, path: "/proxies/export", query: q, credentials: true})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// OccupyProxy occupies the most available proxy matching request.
+// ErrNotFound is returned if there is no matching proxy and ErrProxiesSaturated if all of them are occupied.
+func (c *Client) OccupyProxy(ctx context.Context, req OccupyRequest) (ProxyOccupy, error) {
+	r := request{method: http.MethodPost, path: "/proxies/occupy"}
+	if err := r.jsonBody(req.body()); err != nil {
+		return ProxyOccupy{}, err
+	}
+
+	var occupy ProxyOccupy
+	err := c.doJSON(ctx, r, &occupy)
+	return occupy, err
+}
+
+// OccupyProxies atomically occupies several distinct proxies matching request.
+func (c *Client) OccupyProxies(ctx context.Context, req BatchOccupyRequest) ([]ProxyOccupy, error) {
+	body := struct {
+		occupyRequestBody
+		Count         int64 `json:"count"`
+		DistinctHosts bool  `json:"distinct_hosts,omitempty"`
+		BestEffort    bool  `json:"best_effort,omitempty"`
+	}{
+		occupyRequestBody: req.body(),
+		Count:             req.Count,
+		DistinctHosts:     req.DistinctHosts,
+		BestEffort:        req.BestEffort,
+	}
+
+	r := request{method: http.MethodPost, path: "/proxies/occupy/batch"}
+	if err := r.jsonBody(body); err != nil {
+		return nil, err
+	}
+
+	var occupies []ProxyOccupy
+	err := c.doJSON(ctx, r, &occupies)
+	return occupies, err
+}
+
+// RenewOccupy extends not yet expired occupy with given key by its TTL.
+func (c *Client) RenewOccupy(ctx context.Context, key string) (ProxyOccupy, error) {
+	var occupy ProxyOccupy
+	err := c.doJSON(ctx, request{method: http.MethodPost, path: "/proxies/occupy/" + url.PathEscape(key) + "/renew"}, &occupy)
+	return occupy, err
+}
+
+// BanProxy bans proxy of occupy with given key for domain, zero cooldown means server default.
+func (c *Client) BanProxy(ctx context.Context, key string, domain string, reason string, cooldown time.Duration) (ProxyBan, error) {
+	r := request{method: http.MethodPost, path: "/proxies/occupy/" + url.PathEscape(key) + "/ban"}
+	err := r.jsonBody(struct {
+		Domain   string `json:"domain"`
+		Reason   string `json:"reason,omitempty"`
+		Cooldown int64  `json:"cooldown,omitempty"`
+	}{
+		Domain:   domain,
+		Reason:   reason,
+		Cooldown: int64(cooldown / time.Second),
+	})
+	if err != nil {
+		return ProxyBan{}, err
+	}
+
+	var ban ProxyBan
+	err = c.doJSON(ctx, r, &ban)
+	return ban, err
+}
+
+// ReleaseProxy releases occupy with given key, optional outcome of proxy usage updates proxy score.
+func (c *Client) ReleaseProxy(ctx context.Context, key string, outcome *Outcome) error {
+	type outcomeBody struct {
+		Status  string `json:"status"`
+		Reason  string `json:"reason,omitempty"`
+		Bytes   int64  `json:"bytes,omitempty"`
+		Latency int64  `json:"latency,omitempty"`
+	}
+	body := struct {
+		Key     string       `json:"key"`
+		Outcome *outcomeBody `json:"outcome,omitempty"`
+	}{Key: key}
+	if outcome != nil {
+		body.Outcome = &outcomeBody{
+			Status:  outcome.Status,
+			Reason:  outcome.Reason,
+			Bytes:   outcome.Bytes,
+			Latency: outcome.Latency.Milliseconds(),
+		}
+	}
+
+	r := request{method: http.MethodPost, path: "/proxies/release"}
+	if err := r.jsonBody(body); err != nil {
+		return err
+	}
+	return c.doJSON(ctx, r, nil)
+}